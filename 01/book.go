@@ -0,0 +1,156 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// Describable embeds the fmt.Stringer interface, so anything which satisfies
+// Describable must have both a String() string method and a Name() string
+// method. Embedding lets us build bigger interfaces out of smaller ones.
+type Describable interface {
+	fmt.Stringer
+	Name() string
+}
+
+// Name returns the book title. Together with String(), this means Book
+// satisfies the Describable interface.
+func (b Book) Name() string {
+	return b.Title
+}
+
+// PrintDescribable logs both the name and the string form of d.
+func PrintDescribable(d Describable) {
+	log.Printf("%s: %s", d.Name(), d.String())
+}
+
+// DiffBook returns a human-readable description of the fields which differ
+// between two Books, one field per line. If the books are equal it returns
+// an empty string, which makes it handy for test failure messages.
+func DiffBook(a, b Book) string {
+	var diffs []string
+
+	if a.Title != b.Title {
+		diffs = append(diffs, fmt.Sprintf("Title: %q != %q", a.Title, b.Title))
+	}
+	if a.Author != b.Author {
+		diffs = append(diffs, fmt.Sprintf("Author: %q != %q", a.Author, b.Author))
+	}
+	if a.ID != b.ID {
+		diffs = append(diffs, fmt.Sprintf("ID: %q != %q", a.ID, b.ID))
+	}
+
+	return strings.Join(diffs, "\n")
+}
+
+// Books is a slice of Book which satisfies sort.Interface, ordering the books
+// by title. That means a Books value can be passed to sort.Sort().
+type Books []Book
+
+func (bs Books) Len() int           { return len(bs) }
+func (bs Books) Less(i, j int) bool { return bs[i].Title < bs[j].Title }
+func (bs Books) Swap(i, j int)      { bs[i], bs[j] = bs[j], bs[i] }
+
+// FindBook uses a binary search to find the book with the given title. The
+// books must already be sorted by title (for example with sort.Sort), or the
+// result is undefined.
+func FindBook(sorted Books, title string) (Book, bool) {
+	i := sort.Search(len(sorted), func(i int) bool {
+		return sorted[i].Title >= title
+	})
+	if i < len(sorted) && sorted[i].Title == title {
+		return sorted[i], true
+	}
+	return Book{}, false
+}
+
+// BookAppender is satisfied by anything with an Add(Book) method.
+type BookAppender interface {
+	Add(Book)
+}
+
+// Library is a collection of books.
+type Library struct {
+	Books Books
+}
+
+// Add has a pointer receiver, because it needs to modify the Library. That
+// means Add is in the method set of *Library but NOT in the method set of
+// Library, so only a *Library satisfies the BookAppender interface.
+func (l *Library) Add(b Book) {
+	l.Books = append(l.Books, b)
+}
+
+// Check at compile time that *Library satisfies BookAppender. Writing
+// `var _ BookAppender = Library{}` instead would fail to compile.
+var _ BookAppender = (*Library)(nil)
+
+// bookLineSep separates the title from the author in the line format used by
+// ParseBookLine and FormatBookLine.
+const bookLineSep = " - "
+
+// ParseBookLine parses a line of the form "Title - Author" into a Book. The
+// line is split on the first separator, so the author (but not the title)
+// may itself contain " - ". The title must not be empty.
+func ParseBookLine(line string) (Book, error) {
+	title, author, ok := strings.Cut(line, bookLineSep)
+	if !ok {
+		return Book{}, fmt.Errorf("parse book line %q: missing %q separator", line, bookLineSep)
+	}
+	if title == "" {
+		return Book{}, errors.New("parse book line: empty title")
+	}
+
+	return Book{Title: title, Author: author}, nil
+}
+
+// FormatBookLine formats a Book in the line format understood by
+// ParseBookLine.
+func FormatBookLine(b Book) string {
+	return b.Title + bookLineSep + b.Author
+}
+
+// BooksPerAuthor returns the number of books by each author.
+func BooksPerAuthor(books []Book) map[string]int {
+	return CountBy(books, func(b Book) string { return b.Author })
+}
+
+// Card renders the book as a block of labeled lines, one per field, in the
+// style of a vCard. A type isn't limited to the one rendering that String()
+// gives it: it can offer as many others as are useful.
+func (b Book) Card() string {
+	return fmt.Sprintf("Title: %s\nAuthor: %s\n", b.Title, b.Author)
+}
+
+// ErrEmptyTitle is reported by ValidateBooks for a book with no title.
+var ErrEmptyTitle = errors.New("empty title")
+
+// ValidateBooks checks every book, rather than stopping at the first
+// problem, and returns all of the problems joined into a single error (or
+// nil if there are none). Each one is prefixed with the index of the book,
+// and wraps ErrEmptyTitle, so errors.Is still works on the joined error.
+func ValidateBooks(books []Book) error {
+	var errs []error
+	for i, b := range books {
+		if b.Title == "" {
+			errs = append(errs, fmt.Errorf("book %d: %w", i, ErrEmptyTitle))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// StringerToBook converts any fmt.Stringer into a Book, by rendering it with
+// String() and passing the result to parse. All that is known about s is
+// that it satisfies the interface, so its text is the only way to get at its
+// contents; parse decides how that text maps to a Book (ParseBookLine is one
+// choice).
+func StringerToBook(s fmt.Stringer, parse func(string) (Book, error)) (Book, error) {
+	b, err := parse(s.String())
+	if err != nil {
+		return Book{}, fmt.Errorf("convert %T to Book: %w", s, err)
+	}
+	return b, nil
+}
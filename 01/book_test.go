@@ -0,0 +1,311 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestDiffBook(t *testing.T) {
+	alice := Book{Title: "Alice in Wonderland", Author: "Lewis Carrol"}
+
+	tests := []struct {
+		name string
+		a, b Book
+		exp  string
+	}{
+		{
+			name: "identical",
+			a:    alice,
+			b:    alice,
+			exp:  "",
+		},
+		{
+			name: "title only",
+			a:    alice,
+			b:    Book{Title: "Through the Looking-Glass", Author: "Lewis Carrol"},
+			exp:  `Title: "Alice in Wonderland" != "Through the Looking-Glass"`,
+		},
+		{
+			name: "both fields",
+			a:    alice,
+			b:    Book{Title: "Emma", Author: "Jane Austen"},
+			exp:  "Title: \"Alice in Wonderland\" != \"Emma\"\nAuthor: \"Lewis Carrol\" != \"Jane Austen\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := DiffBook(tt.a, tt.b)
+			if diff != tt.exp {
+				t.Fatalf("got %q; expected %q", diff, tt.exp)
+			}
+		})
+	}
+}
+
+// captureLog redirects the standard logger to a buffer for the duration of the
+// test.
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	out, flags := log.Writer(), log.Flags()
+	log.SetOutput(&buf)
+	log.SetFlags(0)
+	t.Cleanup(func() {
+		log.SetOutput(out)
+		log.SetFlags(flags)
+	})
+
+	return &buf
+}
+
+func TestPrintDescribable(t *testing.T) {
+	buf := captureLog(t)
+
+	book := Book{Title: "Alice in Wonderland", Author: "Lewis Carrol"}
+	PrintDescribable(book)
+
+	for _, want := range []string{book.Name(), book.String()} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("log output %q does not contain %q", buf.String(), want)
+		}
+	}
+}
+
+func TestFindBook(t *testing.T) {
+	books := Books{
+		{Title: "Middlemarch", Author: "George Eliot"},
+		{Title: "Emma", Author: "Jane Austen"},
+		{Title: "Alice in Wonderland", Author: "Lewis Carrol"},
+		{Title: "Wuthering Heights", Author: "Emily Bronte"},
+	}
+	sort.Sort(books)
+
+	tests := []struct {
+		name  string
+		title string
+		found bool
+	}{
+		{"first", "Alice in Wonderland", true},
+		{"middle", "Emma", true},
+		{"last", "Wuthering Heights", true},
+		{"not found", "Persuasion", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, ok := FindBook(books, tt.title)
+			if ok != tt.found {
+				t.Fatalf("got found %v; expected %v", ok, tt.found)
+			}
+			if ok && b.Title != tt.title {
+				t.Fatalf("got %q; expected %q", b.Title, tt.title)
+			}
+		})
+	}
+}
+
+func TestLibraryMethodSets(t *testing.T) {
+	appender := reflect.TypeOf((*BookAppender)(nil)).Elem()
+
+	if !reflect.TypeOf(&Library{}).Implements(appender) {
+		t.Fatal("expected *Library to satisfy BookAppender")
+	}
+	if reflect.TypeOf(Library{}).Implements(appender) {
+		t.Fatal("expected Library not to satisfy BookAppender")
+	}
+}
+
+func TestLibraryAdd(t *testing.T) {
+	lib := &Library{}
+
+	var ba BookAppender = lib
+	ba.Add(Book{Title: "Alice in Wonderland", Author: "Lewis Carrol"})
+	ba.Add(Book{Title: "Emma", Author: "Jane Austen"})
+
+	if len(lib.Books) != 2 {
+		t.Fatalf("got %d books; expected %d", len(lib.Books), 2)
+	}
+	if lib.Books[1].Title != "Emma" {
+		t.Fatalf("got %q; expected %q", lib.Books[1].Title, "Emma")
+	}
+}
+
+func TestParseBookLine(t *testing.T) {
+	b, err := ParseBookLine("Alice in Wonderland - Lewis Carrol")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := DiffBook(b, Book{Title: "Alice in Wonderland", Author: "Lewis Carrol"}); diff != "" {
+		t.Fatal(diff)
+	}
+
+	for _, line := range []string{"", "Alice in Wonderland", " - Lewis Carrol"} {
+		_, err := ParseBookLine(line)
+		if err == nil {
+			t.Fatalf("ParseBookLine(%q): expected an error; got nil", line)
+		}
+	}
+}
+
+func FuzzParseBookLine(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"Alice in Wonderland - Lewis Carrol",
+		" - ",
+		" -  -  - ",
+		"- - - - -",
+		"Title - ",
+		"Title -",
+		"Title - Author - With - Dashes",
+		"\x00 - \xff",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, line string) {
+		b, err := ParseBookLine(line)
+		if err != nil {
+			return
+		}
+
+		// Any book that parsed successfully must survive a round trip
+		// through the line format.
+		got, err := ParseBookLine(FormatBookLine(b))
+		if err != nil {
+			t.Fatalf("round trip of %q failed: %v", line, err)
+		}
+		if diff := DiffBook(got, b); diff != "" {
+			t.Fatalf("round trip of %q changed the book:\n%s", line, diff)
+		}
+	})
+}
+
+func TestBooksPerAuthor(t *testing.T) {
+	tests := []struct {
+		name  string
+		books []Book
+		exp   map[string]int
+	}{
+		{
+			"repeated authors",
+			[]Book{
+				{Title: "Emma", Author: "Jane Austen"},
+				{Title: "Alice in Wonderland", Author: "Lewis Carrol"},
+				{Title: "Persuasion", Author: "Jane Austen"},
+				{Title: "Middlemarch", Author: "George Eliot"},
+				{Title: "Pride and Prejudice", Author: "Jane Austen"},
+			},
+			map[string]int{"Jane Austen": 3, "Lewis Carrol": 1, "George Eliot": 1},
+		},
+		{
+			"single author",
+			[]Book{{Title: "Emma", Author: "Jane Austen"}, {Title: "Persuasion", Author: "Jane Austen"}},
+			map[string]int{"Jane Austen": 2},
+		},
+		{"no books", nil, map[string]int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BooksPerAuthor(tt.books); !reflect.DeepEqual(got, tt.exp) {
+				t.Fatalf("got %v; expected %v", got, tt.exp)
+			}
+		})
+	}
+}
+
+func TestBookCard(t *testing.T) {
+	b := Book{Title: "Alice in Wonderland", Author: "Lewis Carrol"}
+	card := b.Card()
+
+	lines := strings.Split(strings.TrimSuffix(card, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines; expected 2:\n%s", len(lines), card)
+	}
+	if exp := "Title: Alice in Wonderland"; lines[0] != exp {
+		t.Fatalf("got %q; expected %q", lines[0], exp)
+	}
+	if exp := "Author: Lewis Carrol"; lines[1] != exp {
+		t.Fatalf("got %q; expected %q", lines[1], exp)
+	}
+
+	// The card is a different rendering from String().
+	if card == b.String() {
+		t.Fatalf("Card() and String() both returned %q", card)
+	}
+}
+
+func TestValidateBooks(t *testing.T) {
+	tests := []struct {
+		name    string
+		books   []Book
+		indexes []int
+	}{
+		{"all valid", []Book{{Title: "Emma", Author: "Jane Austen"}, {Title: "Middlemarch", Author: "George Eliot"}}, nil},
+		{"one invalid", []Book{{Title: "Emma", Author: "Jane Austen"}, {Title: "", Author: "George Eliot"}}, []int{1}},
+		{"multiple invalid", []Book{{Title: "", Author: "Anonymous"}, {Title: "Emma", Author: "Jane Austen"}, {Title: "", Author: ""}}, []int{0, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBooks(tt.books)
+
+			if len(tt.indexes) == 0 {
+				if err != nil {
+					t.Fatalf("got %v; expected nil", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrEmptyTitle) {
+				t.Fatalf("got %v; expected it to wrap %v", err, ErrEmptyTitle)
+			}
+			lines := strings.Split(err.Error(), "\n")
+			if len(lines) != len(tt.indexes) {
+				t.Fatalf("got %d errors (%v); expected %d", len(lines), err, len(tt.indexes))
+			}
+			for i, idx := range tt.indexes {
+				if exp := fmt.Sprintf("book %d: ", idx); !strings.HasPrefix(lines[i], exp) {
+					t.Fatalf("got %q; expected it to start with %q", lines[i], exp)
+				}
+			}
+		})
+	}
+}
+
+// bookLine is a fmt.Stringer whose output is in the ParseBookLine format.
+type bookLine struct {
+	title, author string
+}
+
+func (l bookLine) String() string {
+	return l.title + bookLineSep + l.author
+}
+
+func TestStringerToBook(t *testing.T) {
+	got, err := StringerToBook(bookLine{"Emma", "Jane Austen"}, ParseBookLine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := (Book{Title: "Emma", Author: "Jane Austen"}); got != exp {
+		t.Fatalf("got %v; expected %v", got, exp)
+	}
+}
+
+func TestStringerToBookError(t *testing.T) {
+	_, err := StringerToBook(Count(3), ParseBookLine)
+	if err == nil {
+		t.Fatal("expected an error; got nil")
+	}
+	if !strings.Contains(err.Error(), "main.Count") {
+		t.Fatalf("error %q does not mention the Stringer's type", err)
+	}
+}
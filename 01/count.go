@@ -0,0 +1,80 @@
+package main
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// Count satisfies the sql.Scanner and driver.Valuer interfaces, which lets it
+// be read from and written to a database directly, just like the built-in
+// types.
+var (
+	_ sql.Scanner   = (*Count)(nil)
+	_ driver.Valuer = Count(0)
+)
+
+// Scan implements sql.Scanner. Database drivers return integer columns as
+// int64, but some return them as the raw []byte text instead, so both are
+// handled.
+func (c *Count) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case int64:
+		*c = Count(src)
+		return nil
+	case []byte:
+		n, err := strconv.Atoi(string(src))
+		if err != nil {
+			return fmt.Errorf("scan Count: %w", err)
+		}
+		*c = Count(n)
+		return nil
+	default:
+		return fmt.Errorf("scan Count: unsupported source type %T", src)
+	}
+}
+
+// Value implements driver.Valuer.
+func (c Count) Value() (driver.Value, error) {
+	return int64(c), nil
+}
+
+// MedianCount returns the median of counts. For an even number of counts it
+// is the average of the two middle values. The caller's slice is not
+// modified, because the counts are sorted in a copy.
+func MedianCount(counts []Count) (float64, error) {
+	if len(counts) == 0 {
+		return 0, ErrEmptySlice
+	}
+
+	sorted := slices.Clone(counts)
+	slices.Sort(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return float64(sorted[mid]), nil
+	}
+	return (float64(sorted[mid-1]) + float64(sorted[mid])) / 2, nil
+}
+
+// GeometricMeanCount returns the geometric mean of counts: the nth root of
+// their product. It is undefined if any count is zero or negative, so those
+// are an error. The logarithms of the counts are averaged rather than
+// multiplying them together, which could easily overflow.
+func GeometricMeanCount(counts []Count) (float64, error) {
+	if len(counts) == 0 {
+		return 0, ErrEmptySlice
+	}
+
+	var sumLog float64
+	for i, c := range counts {
+		if c <= 0 {
+			return 0, fmt.Errorf("geometric mean: count %d is %d, not positive", i, c)
+		}
+		sumLog += math.Log(float64(c))
+	}
+	return math.Exp(sumLog / float64(len(counts))), nil
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestCountScan(t *testing.T) {
+	tests := []struct {
+		name string
+		src  interface{}
+		exp  Count
+	}{
+		{"int64", int64(42), 42},
+		{"bytes", []byte("42"), 42},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c Count
+			err := c.Scan(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c != tt.exp {
+				t.Fatalf("got %v; expected %v", c, tt.exp)
+			}
+		})
+	}
+}
+
+func TestCountScanErrors(t *testing.T) {
+	for _, src := range []interface{}{[]byte("forty-two"), "42", nil} {
+		var c Count
+		err := c.Scan(src)
+		if err == nil {
+			t.Fatalf("Scan(%#v): expected an error; got nil", src)
+		}
+	}
+}
+
+func TestCountValue(t *testing.T) {
+	v, err := Count(42).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != int64(42) {
+		t.Fatalf("got %#v; expected %#v", v, int64(42))
+	}
+
+	// Valuing and then scanning should round-trip.
+	var c Count
+	err = c.Scan(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c != 42 {
+		t.Fatalf("got %v; expected %v", c, 42)
+	}
+}
+
+func TestMedianCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts []Count
+		exp    float64
+	}{
+		{"single", []Count{7}, 7},
+		{"odd", []Count{9, 1, 5}, 5},
+		{"even", []Count{4, 1, 3, 2}, 2.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := slices.Clone(tt.counts)
+
+			got, err := MedianCount(tt.counts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.exp {
+				t.Fatalf("got %v; expected %v", got, tt.exp)
+			}
+			if !slices.Equal(tt.counts, before) {
+				t.Fatalf("input was modified: got %v; expected %v", tt.counts, before)
+			}
+		})
+	}
+}
+
+func TestMedianCountEmpty(t *testing.T) {
+	_, err := MedianCount(nil)
+	if !errors.Is(err, ErrEmptySlice) {
+		t.Fatalf("got %v; expected %v", err, ErrEmptySlice)
+	}
+}
+
+func TestGeometricMeanCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts []Count
+		exp    float64
+	}{
+		{"single", []Count{5}, 5},
+		{"pair", []Count{2, 8}, 4},
+		{"three", []Count{1, 3, 9}, 3},
+		{"large", []Count{1 << 40, 1 << 40, 1 << 40}, 1 << 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GeometricMeanCount(tt.counts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(got-tt.exp) > 1e-9*tt.exp {
+				t.Fatalf("got %v; expected %v", got, tt.exp)
+			}
+		})
+	}
+}
+
+func TestGeometricMeanCountErrors(t *testing.T) {
+	_, err := GeometricMeanCount(nil)
+	if !errors.Is(err, ErrEmptySlice) {
+		t.Fatalf("got %v; expected %v", err, ErrEmptySlice)
+	}
+
+	for _, counts := range [][]Count{{4, 0, 2}, {4, -1}} {
+		_, err = GeometricMeanCount(counts)
+		if err == nil {
+			t.Fatalf("%v: expected an error; got nil", counts)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// markdownEscaper escapes the pipe characters which would otherwise end a
+// Markdown table cell early.
+var markdownEscaper = strings.NewReplacer("|", `\|`)
+
+// RenderMarkdown writes the books to w as a Markdown table. Because it
+// accepts an io.Writer, the table can be written to a file, a buffer, an HTTP
+// response or anything else which satisfies the interface.
+func RenderMarkdown(w io.Writer, books []Book) error {
+	_, err := io.WriteString(w, "| Title | Author |\n| --- | --- |\n")
+	if err != nil {
+		return err
+	}
+
+	for _, b := range books {
+		_, err = fmt.Fprintf(w, "| %s | %s |\n", markdownEscaper.Replace(b.Title), markdownEscaper.Replace(b.Author))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportBooksNDJSON writes the books to w as newline-delimited JSON, with one
+// JSON object per line.
+func ExportBooksNDJSON(w io.Writer, books []Book) error {
+	// A json.Encoder writes a newline after every value it encodes, which
+	// is exactly the NDJSON format.
+	enc := json.NewEncoder(w)
+	for _, b := range books {
+		err := enc.Encode(b)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ImportBooksNDJSON reads newline-delimited JSON books from r. Blank lines
+// are skipped. If a line cannot be decoded, the error reports its line number.
+func ImportBooksNDJSON(r io.Reader) ([]Book, error) {
+	var books []Book
+
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+
+		var b Book
+		err := json.Unmarshal(line, &b)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		books = append(books, b)
+	}
+
+	return books, sc.Err()
+}
+
+// ImportBooksCSV reads books from CSV in the format written by
+// CSVSerializer: a "Title,Author" header row followed by one book per row.
+func ImportBooksCSV(r io.Reader) ([]Book, error) {
+	var books []Book
+	err := readBooksCSV(r, func(_ int, b Book) {
+		books = append(books, b)
+	})
+	return books, err
+}
+
+// ImportBooksCSVUnique is like ImportBooksCSV, but skips any book with the
+// same title and author as one earlier in the input. It also returns the
+// line numbers of the skipped duplicates, so that they can be reported.
+func ImportBooksCSVUnique(r io.Reader) ([]Book, []int, error) {
+	var books []Book
+	var skipped []int
+	seen := make(map[Book]bool)
+
+	err := readBooksCSV(r, func(line int, b Book) {
+		if seen[b] {
+			skipped = append(skipped, line)
+			return
+		}
+		seen[b] = true
+		books = append(books, b)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return books, skipped, nil
+}
+
+// readBooksCSV checks the header row, and then calls fn with each book and
+// the line number it started on. Empty input has no books, so it isn't an
+// error, but anything else must start with the header.
+func readBooksCSV(r io.Reader, fn func(line int, b Book)) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 2
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read CSV header: %w", err)
+	}
+	if header[0] != "Title" || header[1] != "Author" {
+		return fmt.Errorf("unexpected CSV header %q", header)
+	}
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// A quoted field can span several lines, so ask the reader where
+		// the record started rather than counting rows.
+		line, _ := cr.FieldPos(0)
+		fn(line, Book{Title: record[0], Author: record[1]})
+	}
+}
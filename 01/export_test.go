@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	books := []Book{
+		{Title: "Alice in Wonderland", Author: "Lewis Carrol"},
+		{Title: "Either|Or", Author: "Søren Kierkegaard"},
+	}
+
+	var buf bytes.Buffer
+	err := RenderMarkdown(&buf, books)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := []string{
+		"| Title | Author |",
+		"| --- | --- |",
+		"| Alice in Wonderland | Lewis Carrol |",
+		`| Either\|Or | Søren Kierkegaard |`,
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(exp) {
+		t.Fatalf("got %q; expected %q", lines, exp)
+	}
+	for i := range exp {
+		if lines[i] != exp[i] {
+			t.Fatalf("line %d: got %q; expected %q", i, lines[i], exp[i])
+		}
+	}
+}
+
+func TestBooksNDJSONRoundTrip(t *testing.T) {
+	books := []Book{
+		{Title: "Alice in Wonderland", Author: "Lewis Carrol"},
+		{Title: "Emma", Author: "Jane Austen"},
+	}
+
+	var buf bytes.Buffer
+	err := ExportBooksNDJSON(&buf, books)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if lines := strings.Count(buf.String(), "\n"); lines != len(books) {
+		t.Fatalf("got %d lines; expected %d", lines, len(books))
+	}
+
+	got, err := ImportBooksNDJSON(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(books) {
+		t.Fatalf("got %v; expected %v", got, books)
+	}
+	for i := range books {
+		if diff := DiffBook(got[i], books[i]); diff != "" {
+			t.Fatalf("book %d:\n%s", i, diff)
+		}
+	}
+}
+
+func TestImportBooksNDJSONBlankLines(t *testing.T) {
+	input := "\n{\"Title\":\"Emma\",\"Author\":\"Jane Austen\"}\n   \n\n"
+
+	got, err := ImportBooksNDJSON(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Title != "Emma" {
+		t.Fatalf("got %v; expected only Emma", got)
+	}
+}
+
+func TestImportBooksNDJSONMalformed(t *testing.T) {
+	input := "{\"Title\":\"Emma\",\"Author\":\"Jane Austen\"}\n\n{\"Title\": oops}\n"
+
+	_, err := ImportBooksNDJSON(strings.NewReader(input))
+	if err == nil {
+		t.Fatal("expected an error; got nil")
+	}
+	if !strings.Contains(err.Error(), "line 3") {
+		t.Fatalf("error %q does not mention line 3", err)
+	}
+}
+
+func TestImportBooksCSV(t *testing.T) {
+	books := []Book{
+		{Title: "Alice in Wonderland", Author: "Lewis Carrol"},
+		{Title: "Pride, and Prejudice", Author: "Jane Austen"},
+	}
+
+	var buf bytes.Buffer
+	err := CSVSerializer{}.Serialize(&buf, books)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ImportBooksCSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !SliceEqual(got, books) {
+		t.Fatalf("got %v; expected %v", got, books)
+	}
+}
+
+func TestImportBooksCSVBadHeader(t *testing.T) {
+	_, err := ImportBooksCSV(strings.NewReader("Name,Writer\nEmma,Jane Austen\n"))
+	if err == nil {
+		t.Fatal("expected an error; got nil")
+	}
+}
+
+func TestImportBooksCSVEmpty(t *testing.T) {
+	// An export of no books is just the header row.
+	var header bytes.Buffer
+	err := CSVSerializer{}.Serialize(&header, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, input := range []string{"", header.String()} {
+		books, err := ImportBooksCSV(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("ImportBooksCSV(%q): %v", input, err)
+		}
+		if len(books) != 0 {
+			t.Fatalf("ImportBooksCSV(%q): got %v; expected no books", input, books)
+		}
+
+		books, skipped, err := ImportBooksCSVUnique(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("ImportBooksCSVUnique(%q): %v", input, err)
+		}
+		if len(books) != 0 || len(skipped) != 0 {
+			t.Fatalf("ImportBooksCSVUnique(%q): got %v and skipped %v; expected neither", input, books, skipped)
+		}
+	}
+}
+
+func TestImportBooksCSVUnique(t *testing.T) {
+	input := `Title,Author
+Emma,Jane Austen
+Middlemarch,George Eliot
+Emma,Jane Austen
+Emma,Someone Else
+"Middle
+march",George Eliot
+Middlemarch,George Eliot
+`
+
+	books, skipped, err := ImportBooksCSVUnique(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := []Book{
+		{Title: "Emma", Author: "Jane Austen"},
+		{Title: "Middlemarch", Author: "George Eliot"},
+		{Title: "Emma", Author: "Someone Else"},
+		{Title: "Middle\nmarch", Author: "George Eliot"},
+	}
+	if !SliceEqual(books, exp) {
+		t.Fatalf("got %q; expected %q", books, exp)
+	}
+
+	// The quoted title spans lines 6 and 7, so the last row is line 8.
+	if expLines := []int{4, 8}; !SliceEqual(skipped, expLines) {
+		t.Fatalf("got skipped lines %v; expected %v", skipped, expLines)
+	}
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+)
+
+// ErrEmptySlice is returned by helpers which need at least one item to work
+// with.
+var ErrEmptySlice = errors.New("empty slice")
+
+// Contains reports whether target is present in items. Because both Book and
+// Count are comparable types (they can be compared with ==), they can be used
+// directly as the type parameter T. For a Book, that includes its ID.
+func Contains[T comparable](items []T, target T) bool {
+	for _, item := range items {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
+
+// Min returns the smallest of the items. The cmp.Ordered constraint allows any
+// type whose underlying type supports the < operator, which includes Count
+// (because its underlying type is int).
+func Min[T cmp.Ordered](items []T) (T, error) {
+	if len(items) == 0 {
+		var zero T
+		return zero, ErrEmptySlice
+	}
+
+	m := items[0]
+	for _, item := range items[1:] {
+		if item < m {
+			m = item
+		}
+	}
+	return m, nil
+}
+
+// Max returns the largest of the items.
+func Max[T cmp.Ordered](items []T) (T, error) {
+	if len(items) == 0 {
+		var zero T
+		return zero, ErrEmptySlice
+	}
+
+	m := items[0]
+	for _, item := range items[1:] {
+		if item > m {
+			m = item
+		}
+	}
+	return m, nil
+}
+
+// Partition splits items into those for which pred returns true and those for
+// which it returns false. The relative order of the items is preserved within
+// each of the returned slices.
+func Partition[T any](items []T, pred func(T) bool) (matched, rest []T) {
+	for _, item := range items {
+		if pred(item) {
+			matched = append(matched, item)
+		} else {
+			rest = append(rest, item)
+		}
+	}
+	return matched, rest
+}
+
+// GroupBy groups items by the key returned from the key function. Within each
+// group, items appear in the same order as they did in the input.
+func GroupBy[K comparable, T any](items []T, key func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for _, item := range items {
+		k := key(item)
+		groups[k] = append(groups[k], item)
+	}
+	return groups
+}
+
+// CountBy counts how many items share each key returned from the key
+// function. It is like GroupBy, for when only the size of each group is
+// needed.
+func CountBy[K comparable, T any](items []T, key func(T) K) map[K]int {
+	counts := make(map[K]int)
+	for _, item := range items {
+		counts[key(item)]++
+	}
+	return counts
+}
+
+// FlatMap calls f on each of the items and concatenates the resulting slices
+// into a single slice.
+func FlatMap[T, U any](items []T, f func(T) []U) []U {
+	var out []U
+	for _, item := range items {
+		out = append(out, f(item)...)
+	}
+	return out
+}
+
+// Dedupe returns a new slice containing the items with any duplicates
+// removed. The first occurrence of each item is kept, and the order of the
+// items is preserved.
+func Dedupe[T comparable](items []T) []T {
+	seen := make(map[T]bool, len(items))
+	out := make([]T, 0, len(items))
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// Chunk splits items into consecutive batches of the given size, for example
+// to insert books into a store a few at a time. The final batch may be
+// shorter than size. The batches share memory with items.
+func Chunk[T any](items []T, size int) ([][]T, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("chunk size must be positive, got %d", size)
+	}
+
+	var chunks [][]T
+	for len(items) > size {
+		chunks = append(chunks, items[:size:size])
+		items = items[size:]
+	}
+	if len(items) > 0 {
+		chunks = append(chunks, items)
+	}
+	return chunks, nil
+}
+
+// Windows returns every run of size consecutive items, in order, so that
+// each window overlaps the next by all but one item. This is the shape that
+// moving statistics, such as a moving average, work over. If size is larger
+// than the number of items, there are no windows. Like Chunk, the windows
+// share memory with items.
+func Windows[T any](items []T, size int) ([][]T, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("window size must be positive, got %d", size)
+	}
+
+	var windows [][]T
+	for i := 0; i+size <= len(items); i++ {
+		windows = append(windows, items[i:i+size:i+size])
+	}
+	return windows, nil
+}
+
+// Zip pairs up the elements of as and bs by index. If the slices have
+// different lengths, the extra elements of the longer one are ignored.
+func Zip[A, B any](as []A, bs []B) []struct {
+	First  A
+	Second B
+} {
+	n := min(len(as), len(bs))
+
+	out := make([]struct {
+		First  A
+		Second B
+	}, n)
+	for i := 0; i < n; i++ {
+		out[i].First = as[i]
+		out[i].Second = bs[i]
+	}
+	return out
+}
+
+// Index returns the index of the first occurrence of target in items, or -1
+// if it isn't present.
+func Index[T comparable](items []T, target T) int {
+	for i, item := range items {
+		if item == target {
+			return i
+		}
+	}
+	return -1
+}
+
+// Reverse reverses items in place.
+func Reverse[T any](items []T) {
+	for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+		items[i], items[j] = items[j], items[i]
+	}
+}
+
+// Reversed returns a reversed copy of items, leaving items itself unchanged.
+// It is handy for showing a list most-recent-first without disturbing the
+// original order.
+func Reversed[T any](items []T) []T {
+	out := make([]T, len(items))
+	for i, item := range items {
+		out[len(items)-1-i] = item
+	}
+	return out
+}
+
+// SliceEqual reports whether a and b have the same length and the same
+// elements in the same order.
+func SliceEqual[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Any reports whether pred returns true for at least one of the items. It is
+// false for an empty slice.
+func Any[T any](items []T, pred func(T) bool) bool {
+	for _, item := range items {
+		if pred(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// All reports whether pred returns true for every one of the items. It is
+// true for an empty slice, because there is no item for which pred fails.
+func All[T any](items []T, pred func(T) bool) bool {
+	for _, item := range items {
+		if !pred(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// First returns the first of the items. If there are none, it returns the
+// zero value of T and false.
+func First[T any](items []T) (T, bool) {
+	if len(items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return items[0], true
+}
+
+// Last returns the last of the items. If there are none, it returns the zero
+// value of T and false.
+func Last[T any](items []T) (T, bool) {
+	if len(items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return items[len(items)-1], true
+}
+
+// Tap calls fn on each of the items, for its side effects, and then returns
+// items unchanged. It lets something like logging be slotted into the middle
+// of a chain of calls without breaking the chain.
+func Tap[T any](items []T, fn func(T)) []T {
+	for _, item := range items {
+		fn(item)
+	}
+	return items
+}
@@ -0,0 +1,531 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestContains(t *testing.T) {
+	books := []Book{
+		{Title: "Alice in Wonderland", Author: "Lewis Carrol"},
+		{Title: "Emma", Author: "Jane Austen"},
+	}
+
+	if !Contains(books, Book{Title: "Emma", Author: "Jane Austen"}) {
+		t.Fatal("expected books to contain Emma")
+	}
+	if Contains(books, Book{Title: "Emma", Author: "Lewis Carrol"}) {
+		t.Fatal("expected books not to contain Emma by Lewis Carrol")
+	}
+
+	counts := []Count{1, 2, 3}
+
+	if !Contains(counts, Count(2)) {
+		t.Fatal("expected counts to contain 2")
+	}
+	if Contains(counts, Count(4)) {
+		t.Fatal("expected counts not to contain 4")
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	tests := []struct {
+		name     string
+		counts   []Count
+		min, max Count
+	}{
+		{"single element", []Count{7}, 7, 7},
+		{"multiple elements", []Count{3, 9, 1, 4}, 1, 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			min, err := Min(tt.counts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if min != tt.min {
+				t.Fatalf("Min: got %v; expected %v", min, tt.min)
+			}
+
+			max, err := Max(tt.counts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if max != tt.max {
+				t.Fatalf("Max: got %v; expected %v", max, tt.max)
+			}
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := Min([]Count{})
+		if !errors.Is(err, ErrEmptySlice) {
+			t.Fatalf("Min: got %v; expected %v", err, ErrEmptySlice)
+		}
+
+		_, err = Max([]Count{})
+		if !errors.Is(err, ErrEmptySlice) {
+			t.Fatalf("Max: got %v; expected %v", err, ErrEmptySlice)
+		}
+	})
+}
+
+func TestPartition(t *testing.T) {
+	books := []Book{
+		{Title: "Alice in Wonderland", Author: "Lewis Carrol"},
+		{Title: "Beowulf", Author: ""},
+		{Title: "Emma", Author: "Jane Austen"},
+		{Title: "The Epic of Gilgamesh", Author: ""},
+	}
+
+	hasAuthor := func(b Book) bool { return b.Author != "" }
+	known, unknown := Partition(books, hasAuthor)
+
+	expKnown := []Book{books[0], books[2]}
+	expUnknown := []Book{books[1], books[3]}
+
+	if len(known) != len(expKnown) || len(unknown) != len(expUnknown) {
+		t.Fatalf("got %v and %v; expected %v and %v", known, unknown, expKnown, expUnknown)
+	}
+	for i := range expKnown {
+		if known[i] != expKnown[i] {
+			t.Fatalf("matched[%d]: got %v; expected %v", i, known[i], expKnown[i])
+		}
+	}
+	for i := range expUnknown {
+		if unknown[i] != expUnknown[i] {
+			t.Fatalf("rest[%d]: got %v; expected %v", i, unknown[i], expUnknown[i])
+		}
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	books := []Book{
+		{Title: "Emma", Author: "Jane Austen"},
+		{Title: "Alice in Wonderland", Author: "Lewis Carrol"},
+		{Title: "Persuasion", Author: "Jane Austen"},
+		{Title: "Through the Looking-Glass", Author: "Lewis Carrol"},
+		{Title: "Pride and Prejudice", Author: "Jane Austen"},
+	}
+
+	byAuthor := GroupBy(books, func(b Book) string { return b.Author })
+
+	exp := map[string][]Book{
+		"Jane Austen":  {books[0], books[2], books[4]},
+		"Lewis Carrol": {books[1], books[3]},
+	}
+
+	if len(byAuthor) != len(exp) {
+		t.Fatalf("got %d groups; expected %d", len(byAuthor), len(exp))
+	}
+	for author, expBooks := range exp {
+		got := byAuthor[author]
+		if len(got) != len(expBooks) {
+			t.Fatalf("%s: got %v; expected %v", author, got, expBooks)
+		}
+		for i := range expBooks {
+			if got[i] != expBooks[i] {
+				t.Fatalf("%s[%d]: got %v; expected %v", author, i, got[i], expBooks[i])
+			}
+		}
+	}
+}
+
+func TestCountBy(t *testing.T) {
+	words := []string{"go", "is", "fun", "and", "so", "are", "interfaces"}
+
+	got := CountBy(words, func(w string) int { return len(w) })
+	exp := map[int]int{2: 3, 3: 3, 10: 1}
+
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got %v; expected %v", got, exp)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	books := []Book{
+		{Title: "Alice in Wonderland", Author: "Lewis Carrol"},
+		{Title: "", Author: "Anonymous"},
+		{Title: "Emma", Author: "Jane Austen"},
+	}
+
+	words := FlatMap(books, func(b Book) []string { return strings.Fields(b.Title) })
+
+	exp := []string{"Alice", "in", "Wonderland", "Emma"}
+	if len(words) != len(exp) {
+		t.Fatalf("got %q; expected %q", words, exp)
+	}
+	for i := range exp {
+		if words[i] != exp[i] {
+			t.Fatalf("words[%d]: got %q; expected %q", i, words[i], exp[i])
+		}
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	alice := Book{Title: "Alice in Wonderland", Author: "Lewis Carrol"}
+	emma := Book{Title: "Emma", Author: "Jane Austen"}
+	persuasion := Book{Title: "Persuasion", Author: "Jane Austen"}
+
+	books := []Book{emma, alice, emma, persuasion, alice, emma}
+
+	got := Dedupe(books)
+
+	exp := []Book{emma, alice, persuasion}
+	if len(got) != len(exp) {
+		t.Fatalf("got %v; expected %v", got, exp)
+	}
+	for i := range exp {
+		if got[i] != exp[i] {
+			t.Fatalf("[%d]: got %v; expected %v", i, got[i], exp[i])
+		}
+	}
+
+	// The input should be left untouched.
+	if len(books) != 6 || books[2] != emma {
+		t.Fatalf("input was modified: %v", books)
+	}
+}
+
+func TestBookEqualityIncludesID(t *testing.T) {
+	// The same book added to a store twice gets two IDs, so the copies
+	// are no longer equal.
+	first := Book{Title: "Emma", Author: "Jane Austen", ID: "1"}
+	second := Book{Title: "Emma", Author: "Jane Austen", ID: "2"}
+	books := []Book{first, second}
+
+	if got := Dedupe(books); len(got) != 2 {
+		t.Fatalf("got %v; expected both copies to be kept", got)
+	}
+	if got := Index(books, second); got != 1 {
+		t.Fatalf("got %d; expected %d", got, 1)
+	}
+	if Contains(books, Book{Title: "Emma", Author: "Jane Austen"}) {
+		t.Fatal("expected a book with no ID not to match")
+	}
+}
+
+func TestChunk(t *testing.T) {
+	books := []Book{
+		{Title: "Alice in Wonderland", Author: "Lewis Carrol"},
+		{Title: "Emma", Author: "Jane Austen"},
+		{Title: "Middlemarch", Author: "George Eliot"},
+		{Title: "Persuasion", Author: "Jane Austen"},
+		{Title: "Wuthering Heights", Author: "Emily Bronte"},
+		{Title: "Through the Looking-Glass", Author: "Lewis Carrol"},
+	}
+
+	tests := []struct {
+		name  string
+		items []Book
+		size  int
+		sizes []int
+	}{
+		{"even division", books, 2, []int{2, 2, 2}},
+		{"short final chunk", books, 4, []int{4, 2}},
+		{"empty input", nil, 3, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks, err := Chunk(tt.items, tt.size)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(chunks) != len(tt.sizes) {
+				t.Fatalf("got %d chunks; expected %d", len(chunks), len(tt.sizes))
+			}
+
+			var i int
+			for c, chunk := range chunks {
+				if len(chunk) != tt.sizes[c] {
+					t.Fatalf("chunk %d: got %d items; expected %d", c, len(chunk), tt.sizes[c])
+				}
+				for _, b := range chunk {
+					if b != tt.items[i] {
+						t.Fatalf("chunk %d: got %v; expected %v", c, b, tt.items[i])
+					}
+					i++
+				}
+			}
+		})
+	}
+}
+
+func TestChunkInvalidSize(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		_, err := Chunk([]Count{1, 2, 3}, size)
+		if err == nil {
+			t.Fatalf("Chunk(size %d): expected an error; got nil", size)
+		}
+	}
+}
+
+func TestWindows(t *testing.T) {
+	books := []Book{
+		{Title: "Emma", Author: "Jane Austen"},
+		{Title: "Middlemarch", Author: "George Eliot"},
+		{Title: "Persuasion", Author: "Jane Austen"},
+	}
+
+	tests := []struct {
+		name string
+		size int
+		exp  [][]Book
+	}{
+		{"size 1", 1, [][]Book{{books[0]}, {books[1]}, {books[2]}}},
+		{"size 2", 2, [][]Book{{books[0], books[1]}, {books[1], books[2]}}},
+		{"size equal to length", 3, [][]Book{books}},
+		{"size larger than length", 4, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Windows(books, tt.size)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.exp) {
+				t.Fatalf("got %d windows; expected %d", len(got), len(tt.exp))
+			}
+			for i := range tt.exp {
+				if !SliceEqual(got[i], tt.exp[i]) {
+					t.Fatalf("window %d: got %v; expected %v", i, got[i], tt.exp[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWindowsInvalidSize(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		_, err := Windows([]Count{1, 2, 3}, size)
+		if err == nil {
+			t.Fatalf("Windows(size %d): expected an error; got nil", size)
+		}
+	}
+}
+
+func TestZip(t *testing.T) {
+	books := []Book{
+		{Title: "Alice in Wonderland", Author: "Lewis Carrol"},
+		{Title: "Emma", Author: "Jane Austen"},
+		{Title: "Middlemarch", Author: "George Eliot"},
+	}
+	inventory := []Count{4, 0, 7}
+
+	tests := []struct {
+		name   string
+		books  []Book
+		counts []Count
+		n      int
+	}{
+		{"equal length", books, inventory, 3},
+		{"shorter first", books[:2], inventory, 2},
+		{"shorter second", books, inventory[:1], 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pairs := Zip(tt.books, tt.counts)
+			if len(pairs) != tt.n {
+				t.Fatalf("got %d pairs; expected %d", len(pairs), tt.n)
+			}
+			for i, p := range pairs {
+				if p.First != tt.books[i] || p.Second != tt.counts[i] {
+					t.Fatalf("pair %d: got (%v, %v); expected (%v, %v)",
+						i, p.First, p.Second, tt.books[i], tt.counts[i])
+				}
+			}
+		})
+	}
+}
+
+func TestIndex(t *testing.T) {
+	books := []Book{
+		{Title: "Alice in Wonderland", Author: "Lewis Carrol"},
+		{Title: "Emma", Author: "Jane Austen"},
+		{Title: "Middlemarch", Author: "George Eliot"},
+	}
+
+	tests := []struct {
+		name   string
+		target Book
+		exp    int
+	}{
+		{"start", books[0], 0},
+		{"middle", books[1], 1},
+		{"end", books[2], 2},
+		{"not found", Book{Title: "Persuasion", Author: "Jane Austen"}, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Index(books, tt.target); got != tt.exp {
+				t.Fatalf("got %d; expected %d", got, tt.exp)
+			}
+		})
+	}
+}
+
+func TestReverse(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []int
+		exp   []int
+	}{
+		{"empty", []int{}, []int{}},
+		{"odd", []int{1, 2, 3}, []int{3, 2, 1}},
+		{"even", []int{1, 2, 3, 4}, []int{4, 3, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reversed must leave its input alone.
+			orig := slices.Clone(tt.items)
+			got := Reversed(tt.items)
+			if !slices.Equal(got, tt.exp) {
+				t.Fatalf("Reversed: got %v; expected %v", got, tt.exp)
+			}
+			if !slices.Equal(tt.items, orig) {
+				t.Fatalf("Reversed modified its input: got %v; expected %v", tt.items, orig)
+			}
+
+			// Reverse must change its input.
+			Reverse(tt.items)
+			if !slices.Equal(tt.items, tt.exp) {
+				t.Fatalf("Reverse: got %v; expected %v", tt.items, tt.exp)
+			}
+		})
+	}
+}
+
+func TestSliceEqual(t *testing.T) {
+	emma := Book{Title: "Emma", Author: "Jane Austen"}
+	alice := Book{Title: "Alice in Wonderland", Author: "Lewis Carrol"}
+	persuasion := Book{Title: "Persuasion", Author: "Jane Austen"}
+
+	tests := []struct {
+		name string
+		a, b []Book
+		exp  bool
+	}{
+		{"both empty", nil, []Book{}, true},
+		{"equal", []Book{emma, alice}, []Book{emma, alice}, true},
+		{"different lengths", []Book{emma, alice}, []Book{emma}, false},
+		{"differing element", []Book{emma, alice}, []Book{emma, persuasion}, false},
+		{"different order", []Book{emma, alice}, []Book{alice, emma}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SliceEqual(tt.a, tt.b); got != tt.exp {
+				t.Fatalf("got %v; expected %v", got, tt.exp)
+			}
+		})
+	}
+}
+
+func TestAnyAll(t *testing.T) {
+	noAuthor := func(b Book) bool { return b.Author == "" }
+	hasTitle := func(b Book) bool { return b.Title != "" }
+
+	tests := []struct {
+		name         string
+		books        []Book
+		anyNoAuthor  bool
+		allHaveTitle bool
+	}{
+		{"empty", nil, false, true},
+		{
+			"complete",
+			[]Book{{Title: "Emma", Author: "Jane Austen"}, {Title: "Middlemarch", Author: "George Eliot"}},
+			false, true,
+		},
+		{
+			"missing author",
+			[]Book{{Title: "Emma", Author: "Jane Austen"}, {Title: "Beowulf", Author: ""}},
+			true, true,
+		},
+		{
+			"missing title",
+			[]Book{{Title: "", Author: "Jane Austen"}, {Title: "Middlemarch", Author: "George Eliot"}},
+			false, false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Any(tt.books, noAuthor); got != tt.anyNoAuthor {
+				t.Fatalf("Any: got %v; expected %v", got, tt.anyNoAuthor)
+			}
+			if got := All(tt.books, hasTitle); got != tt.allHaveTitle {
+				t.Fatalf("All: got %v; expected %v", got, tt.allHaveTitle)
+			}
+		})
+	}
+}
+
+func TestFirstLast(t *testing.T) {
+	books := []Book{
+		{Title: "Emma", Author: "Jane Austen"},
+		{Title: "Middlemarch", Author: "George Eliot"},
+		{Title: "Persuasion", Author: "Jane Austen"},
+	}
+
+	first, ok := First(books)
+	if !ok || first != books[0] {
+		t.Fatalf("First: got %v, %v; expected %v, true", first, ok, books[0])
+	}
+
+	last, ok := Last(books)
+	if !ok || last != books[2] {
+		t.Fatalf("Last: got %v, %v; expected %v, true", last, ok, books[2])
+	}
+
+	// With a single item, it is both the first and the last.
+	if b, _ := First(books[:1]); b != books[0] {
+		t.Fatalf("First: got %v; expected %v", b, books[0])
+	}
+	if b, _ := Last(books[:1]); b != books[0] {
+		t.Fatalf("Last: got %v; expected %v", b, books[0])
+	}
+}
+
+func TestFirstLastEmpty(t *testing.T) {
+	if b, ok := First([]Book{}); ok || b != (Book{}) {
+		t.Fatalf("First: got %v, %v; expected the zero Book, false", b, ok)
+	}
+	if b, ok := Last[Book](nil); ok || b != (Book{}) {
+		t.Fatalf("Last: got %v, %v; expected the zero Book, false", b, ok)
+	}
+}
+
+func TestTap(t *testing.T) {
+	books := []Book{
+		{Title: "Emma", Author: "Jane Austen"},
+		{Title: "", Author: "Anonymous"},
+		{Title: "Middlemarch", Author: "George Eliot"},
+	}
+
+	buf := captureLog(t)
+	titled, _ := Partition(Tap(books, func(b Book) { WriteLog(b) }), func(b Book) bool { return b.Title != "" })
+
+	exp := "Book: Emma - Jane Austen\nBook:  - Anonymous\nBook: Middlemarch - George Eliot\n"
+	if got := buf.String(); got != exp {
+		t.Fatalf("got %q; expected %q", got, exp)
+	}
+	if len(titled) != 2 {
+		t.Fatalf("got %v; expected 2 books with titles", titled)
+	}
+
+	// The returned slice is the same slice, not a copy.
+	tapped := Tap(books, func(Book) {})
+	if len(tapped) != len(books) || &tapped[0] != &books[0] {
+		t.Fatal("Tap did not return the slice unchanged")
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+)
+
+// IDGenerator is implemented by anything which can produce unique IDs.
+// BookStore asks its IDGenerator for the ID of each new book, so how IDs
+// look can be changed by passing a different generator to NewBookStore,
+// without changing the store itself.
+type IDGenerator interface {
+	Next() string
+}
+
+// UUIDGenerator generates random (version 4) UUIDs, such as
+// "0b9c0f3e-6a1d-4a5e-9c8f-2d3b4a5c6d7e". It is the default IDGenerator for
+// a BookStore.
+type UUIDGenerator struct{}
+
+func (UUIDGenerator) Next() string {
+	var b [16]byte
+	// Before Go 1.24, crypto/rand.Read can return an error if the operating
+	// system's random number generator fails. That leaves no way to make a
+	// unique ID, and Next has no error to return, so give up loudly rather
+	// than hand out an all-zero UUID.
+	_, err := rand.Read(b[:])
+	if err != nil {
+		panic(fmt.Sprintf("generate UUID: %v", err))
+	}
+
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // variant 10
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// CounterIDGenerator generates the IDs "1", "2", "3" and so on. Its zero
+// value is ready to use, and it is safe for concurrent use. Its predictable
+// IDs are handy in tests.
+type CounterIDGenerator struct {
+	n atomic.Uint64
+}
+
+func (c *CounterIDGenerator) Next() string {
+	return strconv.FormatUint(c.n.Add(1), 10)
+}
+
+var (
+	_ IDGenerator = UUIDGenerator{}
+	_ IDGenerator = (*CounterIDGenerator)(nil)
+)
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"regexp"
+	"sync"
+	"testing"
+)
+
+func TestCounterIDGenerator(t *testing.T) {
+	var g CounterIDGenerator
+	for _, exp := range []string{"1", "2", "3"} {
+		if got := g.Next(); got != exp {
+			t.Fatalf("got %q; expected %q", got, exp)
+		}
+	}
+}
+
+func TestCounterIDGeneratorConcurrent(t *testing.T) {
+	var g CounterIDGenerator
+
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id := g.Next()
+			mu.Lock()
+			seen[id] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if len(seen) != 100 {
+		t.Fatalf("got %d unique IDs; expected 100", len(seen))
+	}
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestUUIDGenerator(t *testing.T) {
+	var g UUIDGenerator
+
+	a, b := g.Next(), g.Next()
+	for _, id := range []string{a, b} {
+		if !uuidPattern.MatchString(id) {
+			t.Fatalf("%q is not a version 4 UUID", id)
+		}
+	}
+	if a == b {
+		t.Fatalf("got the same ID %q twice", a)
+	}
+}
+
+func TestBookStoreAssignsIDs(t *testing.T) {
+	s, err := NewBookStore(NopPersistence{}, WithIDGenerator(&CounterIDGenerator{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, title := range []string{"Emma", "Middlemarch", "Persuasion"} {
+		err = s.Add(Book{Title: title})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A book which already has an ID keeps it.
+	err = s.Add(Book{Title: "Beowulf", ID: "b-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, exp := range []string{"1", "2", "3", "b-1"} {
+		if got := s.All()[i].ID; got != exp {
+			t.Fatalf("book %d: got ID %q; expected %q", i, got, exp)
+		}
+	}
+}
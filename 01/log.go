@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// WriteLogGrouped logs the items in separate sections, one for Books and one
+// for Counts, with a header before each. A type switch is used to find out
+// the underlying type of each fmt.Stringer. Anything which is neither a Book
+// nor a Count is logged in a final "Other" section.
+func WriteLogGrouped(items []fmt.Stringer) {
+	var books, counts, other []fmt.Stringer
+
+	for _, item := range items {
+		switch item.(type) {
+		case Book:
+			books = append(books, item)
+		case Count:
+			counts = append(counts, item)
+		default:
+			other = append(other, item)
+		}
+	}
+
+	writeLogSection("Books", books)
+	writeLogSection("Counts", counts)
+	writeLogSection("Other", other)
+}
+
+func writeLogSection(header string, items []fmt.Stringer) {
+	if len(items) == 0 {
+		return
+	}
+
+	log.Printf("%s:", header)
+	for _, item := range items {
+		WriteLog(item)
+	}
+}
+
+// WriteLogSlice logs each of the items. Because T is constrained by
+// fmt.Stringer (rather than the parameter having the interface type itself),
+// a []Book can be passed in directly, without first copying it into a
+// []fmt.Stringer.
+func WriteLogSlice[T fmt.Stringer](items []T) {
+	for _, item := range items {
+		log.Print(item.String())
+	}
+}
+
+// logLevels are the levels accepted by WriteLogLevel.
+var logLevels = map[string]bool{
+	"DEBUG": true,
+	"INFO":  true,
+	"WARN":  true,
+	"ERROR": true,
+}
+
+// unknownLevelOnce makes sure the warning about an unknown level is only
+// written the first time it happens.
+var unknownLevelOnce sync.Once
+
+// WriteLogLevel writes s to the standard logger's output in the form
+// "[LEVEL] <RFC 3339 timestamp> <message>". The level must be one of DEBUG,
+// INFO, WARN or ERROR; any other level is treated as INFO, and a warning is
+// written the first time that happens.
+func WriteLogLevel(level string, s fmt.Stringer) {
+	if !logLevels[level] {
+		unknownLevelOnce.Do(func() {
+			writeLogLine("WARN", fmt.Sprintf("unknown log level %q, defaulting to INFO", level))
+		})
+		level = "INFO"
+	}
+
+	writeLogLine(level, s.String())
+}
+
+func writeLogLine(level, msg string) {
+	// This writes straight to the logger's output, rather than using
+	// log.Printf(), so that the standard logger's own date and time prefix
+	// isn't added in front of ours.
+	fmt.Fprintf(log.Writer(), "[%s] %s %s\n", level, time.Now().Format(time.RFC3339), msg)
+}
+
+// logSinks are the destinations which SetLogSink can send log output to.
+// Each one is a function returning an io.Writer, rather than the io.Writer
+// itself, so that os.Stdout and os.Stderr are looked up at the moment the
+// sink is chosen.
+var (
+	logSinksMu sync.RWMutex
+	logSinks   = map[string]func() io.Writer{
+		"stdout":  func() io.Writer { return os.Stdout },
+		"stderr":  func() io.Writer { return os.Stderr },
+		"discard": func() io.Writer { return io.Discard },
+	}
+)
+
+// RegisterLogSink registers a destination for log output which can later be
+// chosen with SetLogSink.
+func RegisterLogSink(name string, sink func() io.Writer) {
+	logSinksMu.Lock()
+	defer logSinksMu.Unlock()
+	logSinks[name] = sink
+}
+
+// SetLogSink sends the standard logger's output, and therefore the output of
+// WriteLog and the other logging functions, to the sink registered under
+// name. The built-in sinks are "stdout", "stderr" and "discard". Because
+// every sink is just an io.Writer, the logging code doesn't need to know or
+// care where its output ends up.
+func SetLogSink(name string) error {
+	logSinksMu.RLock()
+	sink, ok := logSinks[name]
+	logSinksMu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("unknown log sink %q", name)
+	}
+	log.SetOutput(sink())
+	return nil
+}
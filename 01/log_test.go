@@ -0,0 +1,259 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWriteLogGrouped(t *testing.T) {
+	buf := captureLog(t)
+
+	WriteLogGrouped([]fmt.Stringer{
+		Count(3),
+		Book{Title: "Alice in Wonderland", Author: "Lewis Carrol"},
+		Count(7),
+		Book{Title: "Emma", Author: "Jane Austen"},
+	})
+
+	exp := []string{
+		"Books:",
+		"Book: Alice in Wonderland - Lewis Carrol",
+		"Book: Emma - Jane Austen",
+		"Counts:",
+		"3",
+		"7",
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(exp) {
+		t.Fatalf("got %q; expected %q", lines, exp)
+	}
+	for i := range exp {
+		if lines[i] != exp[i] {
+			t.Fatalf("line %d: got %q; expected %q", i, lines[i], exp[i])
+		}
+	}
+}
+
+func TestWriteLogSlice(t *testing.T) {
+	buf := captureLog(t)
+
+	WriteLogSlice([]Count{1, 2, 3})
+
+	exp := "1\n2\n3\n"
+	if buf.String() != exp {
+		t.Fatalf("got %q; expected %q", buf.String(), exp)
+	}
+}
+
+func benchmarkBooks() []Book {
+	books := make([]Book, 1000)
+	for i := range books {
+		books[i] = Book{Title: fmt.Sprintf("Book %d", i), Author: "Lewis Carrol"}
+	}
+	return books
+}
+
+// discardLog sends the standard logger's output to io.Discard, so that the
+// benchmarks measure the cost of calling through to String() rather than the
+// cost of writing the output.
+func discardLog(b *testing.B) {
+	b.Helper()
+
+	out := log.Writer()
+	log.SetOutput(io.Discard)
+	b.Cleanup(func() { log.SetOutput(out) })
+}
+
+// BenchmarkWriteLog passes each Book to WriteLog(), which converts it to the
+// fmt.Stringer interface type on every call.
+func BenchmarkWriteLog(b *testing.B) {
+	discardLog(b)
+	books := benchmarkBooks()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, book := range books {
+			WriteLog(book)
+		}
+	}
+}
+
+// BenchmarkWriteLogSlice passes the whole []Book to the generic
+// WriteLogSlice(), which can call String() without boxing each Book.
+func BenchmarkWriteLogSlice(b *testing.B) {
+	discardLog(b)
+	books := benchmarkBooks()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		WriteLogSlice(books)
+	}
+}
+
+// parseLevelLine splits a line written by WriteLogLevel into its parts,
+// checking that the timestamp is valid RFC 3339.
+func parseLevelLine(t *testing.T, line string) (level, msg string) {
+	t.Helper()
+
+	parts := strings.SplitN(line, " ", 3)
+	if len(parts) != 3 {
+		t.Fatalf("line %q does not have three parts", line)
+	}
+	if !strings.HasPrefix(parts[0], "[") || !strings.HasSuffix(parts[0], "]") {
+		t.Fatalf("line %q does not start with a [LEVEL]", line)
+	}
+	_, err := time.Parse(time.RFC3339, parts[1])
+	if err != nil {
+		t.Fatalf("line %q does not have an RFC 3339 timestamp: %v", line, err)
+	}
+
+	return strings.Trim(parts[0], "[]"), parts[2]
+}
+
+func TestWriteLogLevel(t *testing.T) {
+	buf := captureLog(t)
+
+	WriteLogLevel("DEBUG", Count(3))
+	WriteLogLevel("ERROR", Book{Title: "Alice in Wonderland", Author: "Lewis Carrol"})
+
+	exp := []struct{ level, msg string }{
+		{"DEBUG", "3"},
+		{"ERROR", "Book: Alice in Wonderland - Lewis Carrol"},
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(exp) {
+		t.Fatalf("got %q; expected %d lines", lines, len(exp))
+	}
+	for i := range exp {
+		level, msg := parseLevelLine(t, lines[i])
+		if level != exp[i].level || msg != exp[i].msg {
+			t.Fatalf("line %d: got (%q, %q); expected (%q, %q)", i, level, msg, exp[i].level, exp[i].msg)
+		}
+	}
+}
+
+func TestWriteLogLevelUnknown(t *testing.T) {
+	buf := captureLog(t)
+	unknownLevelOnce = sync.Once{}
+
+	WriteLogLevel("TRACE", Count(1))
+	WriteLogLevel("VERBOSE", Count(2))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+
+	// The warning should only appear once, and both messages should be
+	// written at INFO level.
+	if len(lines) != 3 {
+		t.Fatalf("got %q; expected 3 lines", lines)
+	}
+	if level, _ := parseLevelLine(t, lines[0]); level != "WARN" {
+		t.Fatalf("got level %q; expected %q", level, "WARN")
+	}
+	for i, exp := range []string{"1", "2"} {
+		level, msg := parseLevelLine(t, lines[i+1])
+		if level != "INFO" || msg != exp {
+			t.Fatalf("got (%q, %q); expected (%q, %q)", level, msg, "INFO", exp)
+		}
+	}
+}
+
+// redirectStd replaces *std (os.Stdout or os.Stderr) with a temporary file
+// for the rest of the test, and returns a function which reads back what was
+// written to it.
+func redirectStd(t *testing.T, std **os.File) func() string {
+	t.Helper()
+
+	f, err := os.CreateTemp(t.TempDir(), "std")
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := *std
+	*std = f
+	t.Cleanup(func() {
+		*std = orig
+		f.Close()
+	})
+
+	return func() string {
+		b, err := os.ReadFile(f.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+}
+
+func TestSetLogSink(t *testing.T) {
+	captureLog(t)
+	stdout := redirectStd(t, &os.Stdout)
+	stderr := redirectStd(t, &os.Stderr)
+
+	steps := []struct {
+		sink string
+		msg  Count
+	}{
+		{"stdout", 1},
+		{"stderr", 2},
+		{"discard", 3},
+		{"stdout", 4},
+	}
+	for _, s := range steps {
+		err := SetLogSink(s.sink)
+		if err != nil {
+			t.Fatal(err)
+		}
+		WriteLog(s.msg)
+	}
+
+	if got, exp := stdout(), "1\n4\n"; got != exp {
+		t.Fatalf("stdout: got %q; expected %q", got, exp)
+	}
+	if got, exp := stderr(), "2\n"; got != exp {
+		t.Fatalf("stderr: got %q; expected %q", got, exp)
+	}
+}
+
+func TestSetLogSinkCustom(t *testing.T) {
+	captureLog(t)
+
+	var buf strings.Builder
+	RegisterLogSink("test", func() io.Writer { return &buf })
+	t.Cleanup(func() {
+		logSinksMu.Lock()
+		delete(logSinks, "test")
+		logSinksMu.Unlock()
+	})
+
+	err := SetLogSink("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	WriteLog(Book{Title: "Emma", Author: "Jane Austen"})
+
+	if got, exp := buf.String(), "Book: Emma - Jane Austen\n"; got != exp {
+		t.Fatalf("got %q; expected %q", got, exp)
+	}
+}
+
+func TestSetLogSinkUnknown(t *testing.T) {
+	buf := captureLog(t)
+
+	err := SetLogSink("syslog")
+	if err == nil {
+		t.Fatal("expected an error; got nil")
+	}
+
+	// The output must be left where it was.
+	WriteLog(Count(5))
+	if got, exp := buf.String(), "5\n"; got != exp {
+		t.Fatalf("got %q; expected %q", got, exp)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// Logger is the small part of *log.Logger that the logging functions need.
+// Because it is an interface, anything with a matching Print method can be
+// used instead, such as a logger that writes somewhere else or one that
+// records messages in a test.
+type Logger interface {
+	Print(v ...interface{})
+}
+
+var _ Logger = (*log.Logger)(nil)
+
+// loggerKey is the context key for a Logger. Using an unexported type for
+// the key means that no other package can accidentally use the same key.
+type loggerKey struct{}
+
+// WithLogger returns a copy of ctx which carries l, so that it can be passed
+// down to the functions handling a single request without adding a Logger
+// parameter to every one of them.
+func WithLogger(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// LoggerFrom returns the Logger carried by ctx, or the standard logger if
+// there isn't one.
+func LoggerFrom(ctx context.Context) Logger {
+	l, ok := ctx.Value(loggerKey{}).(Logger)
+	if !ok {
+		return log.Default()
+	}
+	return l
+}
+
+// WriteLogContext is like WriteLog, but writes to the Logger carried by ctx.
+func WriteLogContext(ctx context.Context, s fmt.Stringer) {
+	LoggerFrom(ctx).Print(s.String())
+}
+
+// RingLogger is a Logger which keeps the last size messages in memory, so
+// that recent log output can be inspected from inside the program, for
+// example by a /debug HTTP endpoint. If next is not nil, every message is
+// also passed on to it, so RingLogger can be "teed" in front of another
+// Logger.
+type RingLogger struct {
+	size int
+	next Logger
+
+	mu       sync.Mutex
+	messages []string
+	start    int // index of the oldest message once the buffer is full
+}
+
+var _ Logger = (*RingLogger)(nil)
+
+// NewRingLogger returns a RingLogger which retains the last size messages
+// (at least one) and forwards them to next, which may be nil.
+func NewRingLogger(size int, next Logger) *RingLogger {
+	size = max(size, 1)
+	return &RingLogger{size: size, next: next, messages: make([]string, 0, size)}
+}
+
+// Print records the message in the same way as log.Print formats it.
+func (r *RingLogger) Print(v ...interface{}) {
+	msg := fmt.Sprint(v...)
+
+	r.mu.Lock()
+	if len(r.messages) < r.size {
+		r.messages = append(r.messages, msg)
+	} else {
+		// The buffer is full, so overwrite the oldest message.
+		r.messages[r.start] = msg
+		r.start = (r.start + 1) % r.size
+	}
+	r.mu.Unlock()
+
+	if r.next != nil {
+		r.next.Print(v...)
+	}
+}
+
+// Last returns up to n of the most recently logged messages, oldest first.
+func (r *RingLogger) Last(n int) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n = min(max(n, 0), len(r.messages))
+	out := make([]string, n)
+	for i := range out {
+		idx := (r.start + len(r.messages) - n + i) % len(r.messages)
+		out[i] = r.messages[idx]
+	}
+	return out
+}
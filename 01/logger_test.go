@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"testing"
+)
+
+// recordingLogger is a Logger which keeps the messages it is given.
+type recordingLogger struct {
+	messages []string
+}
+
+func (r *recordingLogger) Print(v ...interface{}) {
+	for _, m := range v {
+		r.messages = append(r.messages, m.(string))
+	}
+}
+
+func TestLoggerFromRoundTrip(t *testing.T) {
+	rec := &recordingLogger{}
+	ctx := WithLogger(context.Background(), rec)
+
+	if got := LoggerFrom(ctx); got != rec {
+		t.Fatalf("got %v; expected %v", got, rec)
+	}
+
+	WriteLogContext(ctx, Book{Title: "Emma", Author: "Jane Austen"})
+	WriteLogContext(ctx, Count(3))
+
+	exp := []string{"Book: Emma - Jane Austen", "3"}
+	if len(rec.messages) != len(exp) {
+		t.Fatalf("got %q; expected %q", rec.messages, exp)
+	}
+	for i := range exp {
+		if rec.messages[i] != exp[i] {
+			t.Fatalf("got %q; expected %q", rec.messages, exp)
+		}
+	}
+}
+
+func TestLoggerFromDefault(t *testing.T) {
+	if got := LoggerFrom(context.Background()); got != log.Default() {
+		t.Fatalf("got %v; expected the standard logger", got)
+	}
+
+	buf := captureLog(t)
+	WriteLogContext(context.Background(), Count(7))
+
+	if got, exp := buf.String(), "7\n"; got != exp {
+		t.Fatalf("got %q; expected %q", got, exp)
+	}
+}
+
+func TestRingLogger(t *testing.T) {
+	next := &recordingLogger{}
+	r := NewRingLogger(3, next)
+
+	ctx := WithLogger(context.Background(), r)
+	for i := 1; i <= 5; i++ {
+		WriteLogContext(ctx, Count(i))
+	}
+
+	tests := []struct {
+		n   int
+		exp []string
+	}{
+		{10, []string{"3", "4", "5"}},
+		{3, []string{"3", "4", "5"}},
+		{2, []string{"4", "5"}},
+		{0, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.n), func(t *testing.T) {
+			if got := r.Last(tt.n); !slices.Equal(got, tt.exp) {
+				t.Fatalf("got %q; expected %q", got, tt.exp)
+			}
+		})
+	}
+
+	// Every message should have been passed on, not just the retained ones.
+	if got := len(next.messages); got != 5 {
+		t.Fatalf("got %d forwarded messages; expected 5", got)
+	}
+}
+
+func TestRingLoggerNotFull(t *testing.T) {
+	r := NewRingLogger(5, nil)
+	r.Print("one")
+	r.Print("two")
+
+	exp := []string{"one", "two"}
+	if got := r.Last(5); !slices.Equal(got, exp) {
+		t.Fatalf("got %q; expected %q", got, exp)
+	}
+}
@@ -15,6 +15,15 @@ type Stringer interface {
 type Book struct {
 	Title  string
 	Author string
+
+	// ID is assigned when the book is added to a BookStore (see store.go).
+	// It is left out of the JSON encoding when it is empty.
+	//
+	// Comparing two Books with == compares every field, so the ID counts
+	// too: Contains, Index and Dedupe (and a map keyed by Book) treat two
+	// copies of the same title and author as different books if their IDs
+	// differ. To match on the title and author alone, clear the IDs first.
+	ID string `json:",omitempty"`
 }
 
 // because it has a Method with the exact signature of Stringer "String() string"
@@ -45,7 +54,7 @@ func WriteLog(s fmt.Stringer) {
 
 func main() {
 	// Initialize a Count object and pass it to WriteLog().
-	book := Book{"Alice in Wonderland", "Lewis Carrol"}
+	book := Book{Title: "Alice in Wonderland", Author: "Lewis Carrol"}
 	WriteLog(book)
 
 	// Initialize a Count object and pass it to WriteLog().
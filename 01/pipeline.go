@@ -0,0 +1,25 @@
+package main
+
+// Pipeline applies a series of transformations to a value, one after the
+// other, in the order that they were added. Each stage has the same simple
+// signature, so stages can be written independently and then composed in
+// whatever combination is needed. The zero value is an empty pipeline which
+// returns its input unchanged.
+type Pipeline[T any] struct {
+	stages []func(T) T
+}
+
+// Add appends a stage to the pipeline. It returns the pipeline so that calls
+// can be chained.
+func (p *Pipeline[T]) Add(stage func(T) T) *Pipeline[T] {
+	p.stages = append(p.stages, stage)
+	return p
+}
+
+// Run passes v through every stage and returns the result.
+func (p *Pipeline[T]) Run(v T) T {
+	for _, stage := range p.stages {
+		v = stage(v)
+	}
+	return v
+}
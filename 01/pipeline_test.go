@@ -0,0 +1,42 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPipeline(t *testing.T) {
+	upperTitle := func(b Book) Book {
+		b.Title = strings.ToUpper(b.Title)
+		return b
+	}
+	trimAuthor := func(b Book) Book {
+		b.Author = strings.TrimSpace(b.Author)
+		return b
+	}
+
+	p := (&Pipeline[Book]{}).Add(upperTitle).Add(trimAuthor)
+
+	got := p.Run(Book{Title: "Emma", Author: "  Jane Austen "})
+	exp := Book{Title: "EMMA", Author: "Jane Austen"}
+	if got != exp {
+		t.Fatalf("got %v; expected %v", got, exp)
+	}
+}
+
+func TestPipelineOrder(t *testing.T) {
+	var p Pipeline[string]
+	p.Add(func(s string) string { return s + "a" })
+	p.Add(func(s string) string { return s + "b" })
+
+	if got, exp := p.Run(">"), ">ab"; got != exp {
+		t.Fatalf("got %q; expected %q", got, exp)
+	}
+}
+
+func TestPipelineEmpty(t *testing.T) {
+	var p Pipeline[Count]
+	if got := p.Run(3); got != 3 {
+		t.Fatalf("got %v; expected 3", got)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"sync"
+)
+
+// BookSerializer is implemented by each of the formats which books can be
+// exported in. Ext returns the file extension, including the leading dot,
+// which is conventionally used for the format.
+type BookSerializer interface {
+	Serialize(w io.Writer, books []Book) error
+	Ext() string
+}
+
+// CSVSerializer writes books as CSV with a "Title,Author" header row.
+type CSVSerializer struct{}
+
+func (CSVSerializer) Serialize(w io.Writer, books []Book) error {
+	cw := csv.NewWriter(w)
+
+	err := cw.Write([]string{"Title", "Author"})
+	if err != nil {
+		return err
+	}
+	for _, b := range books {
+		err = cw.Write([]string{b.Title, b.Author})
+		if err != nil {
+			return err
+		}
+	}
+
+	// The csv.Writer buffers its output, so nothing reaches w until it is
+	// flushed.
+	cw.Flush()
+	return cw.Error()
+}
+
+func (CSVSerializer) Ext() string { return ".csv" }
+
+// JSONSerializer writes books as a single indented JSON array.
+type JSONSerializer struct{}
+
+func (JSONSerializer) Serialize(w io.Writer, books []Book) error {
+	// Make sure no books is written as [] rather than null.
+	if books == nil {
+		books = []Book{}
+	}
+
+	js, err := json.MarshalIndent(books, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(js, '\n'))
+	return err
+}
+
+func (JSONSerializer) Ext() string { return ".json" }
+
+// NDJSONSerializer writes books as newline-delimited JSON, using
+// ExportBooksNDJSON.
+type NDJSONSerializer struct{}
+
+func (NDJSONSerializer) Serialize(w io.Writer, books []Book) error {
+	return ExportBooksNDJSON(w, books)
+}
+
+func (NDJSONSerializer) Ext() string { return ".ndjson" }
+
+var (
+	serializersMu sync.RWMutex
+	serializers   = map[string]BookSerializer{
+		"csv":    CSVSerializer{},
+		"json":   JSONSerializer{},
+		"ndjson": NDJSONSerializer{},
+	}
+)
+
+// RegisterSerializer registers a BookSerializer under name, so that it can be
+// selected by SerializeBooks. Registering a name which already exists
+// replaces the previous serializer.
+func RegisterSerializer(name string, s BookSerializer) {
+	serializersMu.Lock()
+	defer serializersMu.Unlock()
+	serializers[name] = s
+}
+
+// SerializeBooks writes the books to w using the serializer registered under
+// format.
+func SerializeBooks(format string, w io.Writer, books []Book) error {
+	serializersMu.RLock()
+	s, ok := serializers[format]
+	serializersMu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("unknown serializer %q", format)
+	}
+	return s.Serialize(w, books)
+}
+
+// contentTypeSerializers maps each supported media type to the name of the
+// serializer registered for it.
+var contentTypeSerializers = map[string]string{
+	"application/json":     "json",
+	"text/csv":             "csv",
+	"application/x-ndjson": "ndjson",
+}
+
+// SerializeByContentType writes the books to w in the format named by a
+// media type, such as the one from an HTTP Accept or Content-Type header.
+// Parameters like "; charset=utf-8" are allowed and ignored.
+func SerializeByContentType(contentType string, w io.Writer, books []Book) error {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("parse content type %q: %w", contentType, err)
+	}
+
+	name, ok := contentTypeSerializers[mediaType]
+	if !ok {
+		return fmt.Errorf("unsupported content type %q", mediaType)
+	}
+	return SerializeBooks(name, w, books)
+}
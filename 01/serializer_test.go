@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSerializeBooks(t *testing.T) {
+	books := []Book{
+		{Title: "Alice in Wonderland", Author: "Lewis Carrol"},
+		{Title: "Pride, and Prejudice", Author: "Jane Austen"},
+	}
+
+	tests := []struct {
+		format string
+		exp    string
+	}{
+		{"csv", "Title,Author\nAlice in Wonderland,Lewis Carrol\n\"Pride, and Prejudice\",Jane Austen\n"},
+		{"json", `[
+  {
+    "Title": "Alice in Wonderland",
+    "Author": "Lewis Carrol"
+  },
+  {
+    "Title": "Pride, and Prejudice",
+    "Author": "Jane Austen"
+  }
+]
+`},
+		{"ndjson", `{"Title":"Alice in Wonderland","Author":"Lewis Carrol"}
+{"Title":"Pride, and Prejudice","Author":"Jane Austen"}
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := SerializeBooks(tt.format, &buf, books)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.exp {
+				t.Fatalf("got %q; expected %q", got, tt.exp)
+			}
+		})
+	}
+}
+
+func TestSerializeBooksEmptyJSON(t *testing.T) {
+	var buf bytes.Buffer
+	err := SerializeBooks("json", &buf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := buf.String(), "[]\n"; got != exp {
+		t.Fatalf("got %q; expected %q", got, exp)
+	}
+}
+
+func TestSerializeBooksUnknownFormat(t *testing.T) {
+	err := SerializeBooks("yaml", &bytes.Buffer{}, nil)
+	if err == nil {
+		t.Fatal("expected an error; got nil")
+	}
+}
+
+func TestSerializerExt(t *testing.T) {
+	tests := []struct {
+		s   BookSerializer
+		exp string
+	}{
+		{CSVSerializer{}, ".csv"},
+		{JSONSerializer{}, ".json"},
+		{NDJSONSerializer{}, ".ndjson"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.s.Ext(); got != tt.exp {
+			t.Fatalf("%T: got %q; expected %q", tt.s, got, tt.exp)
+		}
+	}
+}
+
+func TestSerializeByContentType(t *testing.T) {
+	books := []Book{{Title: "Emma", Author: "Jane Austen"}}
+
+	tests := []struct {
+		contentType string
+		format      string
+	}{
+		{"application/json", "json"},
+		{"text/csv", "csv"},
+		{"application/x-ndjson", "ndjson"},
+		{"text/csv; charset=utf-8", "csv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			var got, exp bytes.Buffer
+			err := SerializeByContentType(tt.contentType, &got, books)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = SerializeBooks(tt.format, &exp, books)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got.String() != exp.String() {
+				t.Fatalf("got %q; expected %q", got.String(), exp.String())
+			}
+		})
+	}
+}
+
+func TestSerializeByContentTypeUnsupported(t *testing.T) {
+	for _, contentType := range []string{"application/xml", "text/html; charset=utf-8", ""} {
+		err := SerializeByContentType(contentType, &bytes.Buffer{}, nil)
+		if err == nil {
+			t.Fatalf("%q: expected an error; got nil", contentType)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// BookPersistence is implemented by anything which can save and load a list
+// of books. BookStore only depends on this interface, so the storage format
+// can be changed without touching the store logic.
+type BookPersistence interface {
+	Save([]Book) error
+	Load() ([]Book, error)
+}
+
+// JSONFilePersistence saves books as a JSON array in the file at Path.
+type JSONFilePersistence struct {
+	Path string
+}
+
+func (p JSONFilePersistence) Save(books []Book) error {
+	js, err := json.MarshalIndent(books, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p.Path, js, 0o644)
+}
+
+// Load returns the saved books. If the file doesn't exist yet, it returns no
+// books and no error.
+func (p JSONFilePersistence) Load() ([]Book, error) {
+	js, err := os.ReadFile(p.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var books []Book
+	err = json.Unmarshal(js, &books)
+	return books, err
+}
+
+// NopPersistence doesn't persist anything: Save discards the books, and Load
+// always returns none. It is useful when the books only need to live in
+// memory, such as in tests.
+type NopPersistence struct{}
+
+func (NopPersistence) Save([]Book) error     { return nil }
+func (NopPersistence) Load() ([]Book, error) { return nil, nil }
+
+// ErrBookNotFound is returned when a book is not in the store.
+var ErrBookNotFound = errors.New("book not found")
+
+// BookStore holds books keyed by title, and saves them through a
+// BookPersistence after every change. It is safe for concurrent use.
+type BookStore struct {
+	mu    sync.Mutex
+	p     BookPersistence
+	ids   IDGenerator
+	books []Book
+}
+
+// StoreOption configures a BookStore created by NewBookStore.
+type StoreOption func(*BookStore)
+
+// WithIDGenerator makes the store use g to assign IDs to new books, instead
+// of the default UUIDGenerator.
+func WithIDGenerator(g IDGenerator) StoreOption {
+	return func(s *BookStore) {
+		s.ids = g
+	}
+}
+
+// NewBookStore returns a BookStore which loads its initial books from p.
+func NewBookStore(p BookPersistence, opts ...StoreOption) (*BookStore, error) {
+	books, err := p.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load books: %w", err)
+	}
+
+	s := &BookStore{p: p, ids: UUIDGenerator{}, books: books}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// Add adds a book to the store, giving it an ID from the store's
+// IDGenerator unless it already has one. It is an error to add a book with
+// the same title as one that is already there.
+func (s *BookStore) Add(b Book) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.index(b.Title) >= 0 {
+		return fmt.Errorf("add book: %q already exists", b.Title)
+	}
+	if b.ID == "" {
+		b.ID = s.ids.Next()
+	}
+	return s.save(append(s.books, b))
+}
+
+// Get returns the book with the given title.
+func (s *BookStore) Get(title string) (Book, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.index(title)
+	if i < 0 {
+		return Book{}, false
+	}
+	return s.books[i], true
+}
+
+// Update replaces the book which has the same title as b. If b has no ID,
+// the existing book's ID is kept.
+func (s *BookStore) Update(b Book) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.index(b.Title)
+	if i < 0 {
+		return fmt.Errorf("update book %q: %w", b.Title, ErrBookNotFound)
+	}
+
+	// The book keeps the ID it was given when it was added.
+	if b.ID == "" {
+		b.ID = s.books[i].ID
+	}
+
+	books := append([]Book(nil), s.books...)
+	books[i] = b
+	return s.save(books)
+}
+
+// Delete removes the book with the given title.
+func (s *BookStore) Delete(title string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.index(title)
+	if i < 0 {
+		return fmt.Errorf("delete book %q: %w", title, ErrBookNotFound)
+	}
+
+	books := append([]Book(nil), s.books[:i]...)
+	return s.save(append(books, s.books[i+1:]...))
+}
+
+// All returns a copy of all the books in the store, in the order they were
+// added.
+func (s *BookStore) All() []Book {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Book(nil), s.books...)
+}
+
+func (s *BookStore) index(title string) int {
+	for i, b := range s.books {
+		if b.Title == title {
+			return i
+		}
+	}
+	return -1
+}
+
+// save persists books, and only makes them the store's current books if that
+// succeeded.
+func (s *BookStore) save(books []Book) error {
+	err := s.p.Save(books)
+	if err != nil {
+		return fmt.Errorf("save books: %w", err)
+	}
+	s.books = books
+	return nil
+}
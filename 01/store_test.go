@@ -0,0 +1,110 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// testBookStore runs the same sequence of operations against a BookStore,
+// whatever BookPersistence it is using.
+func testBookStore(t *testing.T, p BookPersistence) {
+	t.Helper()
+
+	s, err := NewBookStore(p, WithIDGenerator(&CounterIDGenerator{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alice := Book{Title: "Alice in Wonderland", Author: "Lewis Carrol"}
+	emma := Book{Title: "Emma", Author: "Jane Austen"}
+
+	for _, b := range []Book{alice, emma} {
+		err = s.Add(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = s.Add(alice)
+	if err == nil {
+		t.Fatal("expected an error adding a duplicate; got nil")
+	}
+
+	// Emma was the second book added, so was given the second ID.
+	got, ok := s.Get("Emma")
+	if exp := (Book{Title: "Emma", Author: "Jane Austen", ID: "2"}); !ok || got != exp {
+		t.Fatalf("got (%v, %v); expected (%v, true)", got, ok, exp)
+	}
+
+	err = s.Update(Book{Title: "Alice in Wonderland", Author: "Lewis Carroll"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ = s.Get("Alice in Wonderland")
+	if got.Author != "Lewis Carroll" || got.ID != "1" {
+		t.Fatalf("got %+v; expected author %q and ID %q", got, "Lewis Carroll", "1")
+	}
+
+	err = s.Delete("Emma")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Get("Emma"); ok {
+		t.Fatal("expected Emma to have been deleted")
+	}
+
+	for _, err := range []error{s.Update(emma), s.Delete("Emma")} {
+		if !errors.Is(err, ErrBookNotFound) {
+			t.Fatalf("got %v; expected %v", err, ErrBookNotFound)
+		}
+	}
+
+	all := s.All()
+	if len(all) != 1 || all[0].Title != "Alice in Wonderland" {
+		t.Fatalf("got %v; expected only Alice in Wonderland", all)
+	}
+}
+
+func TestBookStoreNop(t *testing.T) {
+	testBookStore(t, NopPersistence{})
+}
+
+func TestBookStoreJSONFile(t *testing.T) {
+	p := JSONFilePersistence{Path: filepath.Join(t.TempDir(), "books.json")}
+	testBookStore(t, p)
+
+	// The books should have been saved to the file, so a new store using
+	// the same file starts off with them.
+	s, err := NewBookStore(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	all := s.All()
+	if len(all) != 1 || all[0] != (Book{Title: "Alice in Wonderland", Author: "Lewis Carroll", ID: "1"}) {
+		t.Fatalf("got %v; expected only Alice in Wonderland", all)
+	}
+}
+
+// failingPersistence is a BookPersistence whose Save always fails.
+type failingPersistence struct{ NopPersistence }
+
+func (failingPersistence) Save([]Book) error { return errors.New("disk full") }
+
+func TestBookStoreSaveError(t *testing.T) {
+	s, err := NewBookStore(failingPersistence{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.Add(Book{Title: "Emma", Author: "Jane Austen"})
+	if err == nil {
+		t.Fatal("expected an error; got nil")
+	}
+
+	// A failed save should leave the store unchanged.
+	if len(s.All()) != 0 {
+		t.Fatalf("got %v; expected no books", s.All())
+	}
+}
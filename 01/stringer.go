@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Memoized wraps a fmt.Stringer whose String() method might be expensive, and
+// caches the result of the first call. Because it has a String() string method
+// itself, a *Memoized also satisfies fmt.Stringer, so it can be passed to
+// WriteLog() in place of the value that it wraps.
+type Memoized struct {
+	inner  fmt.Stringer
+	once   sync.Once
+	cached string
+}
+
+// NewMemoized returns a Memoized which wraps s.
+func NewMemoized(s fmt.Stringer) *Memoized {
+	return &Memoized{inner: s}
+}
+
+// String returns the cached result of the inner String() method, calling it
+// only once even when used from multiple goroutines at the same time.
+func (m *Memoized) String() string {
+	m.once.Do(func() {
+		m.cached = m.inner.String()
+	})
+	return m.cached
+}
+
+// Memoize returns a wrapper around f which caches its result for each
+// distinct argument. It generalizes Memoized from String() methods to any
+// function of one argument. Even when the wrapper is called from several
+// goroutines at once, f is only called once for each argument; callers with
+// the same argument wait for that call, while callers with different
+// arguments don't hold each other up.
+func Memoize[K comparable, V any](f func(K) V) func(K) V {
+	type entry struct {
+		once sync.Once
+		v    V
+	}
+
+	var mu sync.Mutex
+	cache := make(map[K]*entry)
+
+	return func(k K) V {
+		mu.Lock()
+		e, ok := cache[k]
+		if !ok {
+			e = &entry{}
+			cache[k] = e
+		}
+		mu.Unlock()
+
+		e.once.Do(func() {
+			e.v = f(k)
+		})
+		return e.v
+	}
+}
+
+var (
+	formattersMu sync.RWMutex
+	formatters   = map[string]func(fmt.Stringer) string{
+		"plain": func(s fmt.Stringer) string { return s.String() },
+		"upper": func(s fmt.Stringer) string { return strings.ToUpper(s.String()) },
+	}
+)
+
+// RegisterFormatter registers a named formatter which can later be used by
+// Format(). Registering a name which already exists replaces the previous
+// formatter.
+func RegisterFormatter(name string, f func(fmt.Stringer) string) {
+	formattersMu.Lock()
+	defer formattersMu.Unlock()
+	formatters[name] = f
+}
+
+// Format formats s using the formatter registered under name. Which formatter
+// is used is decided at runtime, but because every formatter works with the
+// fmt.Stringer interface, s can be a Book, a Count or anything else with a
+// String() method.
+func Format(name string, s fmt.Stringer) (string, error) {
+	formattersMu.RLock()
+	f, ok := formatters[name]
+	formattersMu.RUnlock()
+
+	if !ok {
+		return "", fmt.Errorf("unknown formatter %q", name)
+	}
+	return f(s), nil
+}
+
+// NullStringer represents a fmt.Stringer which may be absent, in the same way
+// that sql.NullString represents a string which may be NULL. It satisfies
+// fmt.Stringer itself, so it can be passed to WriteLog() either way.
+type NullStringer struct {
+	S     fmt.Stringer
+	Valid bool // Valid is true if S is set
+}
+
+func (ns NullStringer) String() string {
+	if !ns.Valid {
+		return "<none>"
+	}
+	return ns.S.String()
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// countingStringer counts how many times its String() method is called.
+type countingStringer struct {
+	calls atomic.Int32
+}
+
+func (c *countingStringer) String() string {
+	c.calls.Add(1)
+	return "expensive"
+}
+
+func TestMemoized(t *testing.T) {
+	inner := &countingStringer{}
+	m := NewMemoized(inner)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if s := m.String(); s != "expensive" {
+				t.Errorf("got %q; expected %q", s, "expensive")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls := inner.calls.Load(); calls != 1 {
+		t.Fatalf("inner String() called %d times; expected 1", calls)
+	}
+}
+
+func TestMemoize(t *testing.T) {
+	books := map[string]Book{
+		"Emma":        {Title: "Emma", Author: "Jane Austen"},
+		"Middlemarch": {Title: "Middlemarch", Author: "George Eliot"},
+		"Persuasion":  {Title: "Persuasion", Author: "Jane Austen"},
+	}
+
+	var mu sync.Mutex
+	calls := make(map[string]int)
+
+	// render stands in for an expensive rendering of a book, looked up by
+	// its title.
+	render := Memoize(func(title string) string {
+		mu.Lock()
+		calls[title]++
+		mu.Unlock()
+		return books[title].Card()
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		for title := range books {
+			wg.Add(1)
+			go func(title string) {
+				defer wg.Done()
+				if got, exp := render(title), books[title].Card(); got != exp {
+					t.Errorf("got %q; expected %q", got, exp)
+				}
+			}(title)
+		}
+	}
+	wg.Wait()
+
+	for title := range books {
+		if calls[title] != 1 {
+			t.Fatalf("%s: got %d calls; expected 1", title, calls[title])
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	book := Book{Title: "Alice in Wonderland", Author: "Lewis Carrol"}
+
+	RegisterFormatter("title-only", func(s fmt.Stringer) string {
+		if b, ok := s.(Book); ok {
+			return b.Title
+		}
+		return s.String()
+	})
+
+	tests := []struct {
+		name string
+		s    fmt.Stringer
+		exp  string
+	}{
+		{"plain", book, "Book: Alice in Wonderland - Lewis Carrol"},
+		{"upper", book, "BOOK: ALICE IN WONDERLAND - LEWIS CARROL"},
+		{"title-only", book, "Alice in Wonderland"},
+		{"title-only", Count(3), "3"},
+	}
+
+	for _, tt := range tests {
+		got, err := Format(tt.name, tt.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.exp {
+			t.Fatalf("Format(%q): got %q; expected %q", tt.name, got, tt.exp)
+		}
+	}
+}
+
+func TestFormatUnknown(t *testing.T) {
+	_, err := Format("no-such-formatter", Count(3))
+	if err == nil {
+		t.Fatal("expected an error; got nil")
+	}
+}
+
+func TestNullStringer(t *testing.T) {
+	tests := []struct {
+		name string
+		ns   NullStringer
+		exp  string
+	}{
+		{"valid", NullStringer{S: Count(3), Valid: true}, "3"},
+		{"invalid", NullStringer{}, "<none>"},
+		{"invalid with value", NullStringer{S: Count(3)}, "<none>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ns.String(); got != tt.exp {
+				t.Fatalf("got %q; expected %q", got, tt.exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by a CircuitBreaker while it is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type breakerState int
+
+const (
+	stateClosed breakerState = iota
+	stateOpen
+	stateHalfOpen
+)
+
+// CircuitBreaker wraps another ShopModel and stops calling it after threshold
+// consecutive errors. While open, calls fail fast with ErrCircuitOpen. Once
+// the cooldown has elapsed the breaker becomes half-open and lets calls
+// through again: a success closes the breaker, and a failure opens it for
+// another cooldown period.
+//
+// Because CircuitBreaker satisfies the ShopModel interface itself, it can be
+// dropped in front of any other implementation without the calling code
+// knowing about it.
+type CircuitBreaker struct {
+	inner     ShopModel
+	threshold int
+	cooldown  time.Duration
+	clock     Clock
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+var _ ShopModel = (*CircuitBreaker)(nil)
+
+// NewCircuitBreaker returns a closed CircuitBreaker which wraps inner.
+func NewCircuitBreaker(inner ShopModel, threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		inner:     inner,
+		threshold: threshold,
+		cooldown:  cooldown,
+		clock:     clock,
+	}
+}
+
+func (cb *CircuitBreaker) CountCustomers(since time.Time) (int, error) {
+	return cb.call(func() (int, error) {
+		return cb.inner.CountCustomers(since)
+	})
+}
+
+func (cb *CircuitBreaker) CountSales(since time.Time) (int, error) {
+	return cb.call(func() (int, error) {
+		return cb.inner.CountSales(since)
+	})
+}
+
+func (cb *CircuitBreaker) call(fn func() (int, error)) (int, error) {
+	cb.mu.Lock()
+	if cb.state == stateOpen {
+		if cb.clock.Now().Sub(cb.openedAt) < cb.cooldown {
+			cb.mu.Unlock()
+			return 0, ErrCircuitOpen
+		}
+		cb.state = stateHalfOpen
+	}
+	cb.mu.Unlock()
+
+	n, err := fn()
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if err == nil {
+		cb.state = stateClosed
+		cb.failures = 0
+		return n, nil
+	}
+
+	cb.failures++
+	if cb.state == stateHalfOpen || cb.failures >= cb.threshold {
+		cb.state = stateOpen
+		cb.openedAt = cb.clock.Now()
+	}
+	return n, err
+}
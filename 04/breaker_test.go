@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// flakyShopDB is a ShopModel whose calls fail while err is set.
+type flakyShopDB struct {
+	err   error
+	calls int
+}
+
+func (f *flakyShopDB) CountCustomers(_ time.Time) (int, error) {
+	f.calls++
+	return 1000, f.err
+}
+
+func (f *flakyShopDB) CountSales(_ time.Time) (int, error) {
+	f.calls++
+	return 333, f.err
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	errDown := errors.New("database is down")
+
+	now := time.Date(2024, 3, 19, 19, 0, 0, 0, time.UTC)
+	fc := NewFakeClock(now)
+	inner := &flakyShopDB{err: errDown}
+
+	cb := NewCircuitBreaker(inner, 3, time.Minute)
+	cb.clock = fc
+
+	// Closed: errors from the inner ShopModel are passed through until the
+	// threshold is reached.
+	for i := 0; i < 3; i++ {
+		_, err := cb.CountSales(now)
+		if !errors.Is(err, errDown) {
+			t.Fatalf("call %d: got %v; expected %v", i, err, errDown)
+		}
+	}
+
+	// Open: calls fail fast without reaching the inner ShopModel.
+	_, err := cb.CountSales(now)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got %v; expected %v", err, ErrCircuitOpen)
+	}
+	if inner.calls != 3 {
+		t.Fatalf("inner called %d times; expected %d", inner.calls, 3)
+	}
+
+	// Half-open: after the cooldown a trial call is let through, and a
+	// failure re-opens the breaker.
+	fc.Advance(time.Minute)
+	_, err = cb.CountSales(now)
+	if !errors.Is(err, errDown) {
+		t.Fatalf("got %v; expected %v", err, errDown)
+	}
+	_, err = cb.CountSales(now)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got %v; expected %v", err, ErrCircuitOpen)
+	}
+
+	// Half-open again, and this time the trial call succeeds, which closes
+	// the breaker.
+	fc.Advance(time.Minute)
+	inner.err = nil
+	n, err := cb.CountSales(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 333 {
+		t.Fatalf("got %v; expected %v", n, 333)
+	}
+
+	// Closed: a single failure no longer trips the breaker.
+	inner.err = errDown
+	_, err = cb.CountCustomers(now)
+	if !errors.Is(err, errDown) {
+		t.Fatalf("got %v; expected %v", err, errDown)
+	}
+	inner.err = nil
+	_, err = cb.CountCustomers(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is implemented by anything which can tell the time. Code that needs
+// the current time asks the package-level clock rather than calling
+// time.Now() directly, so that tests can swap in a FakeClock and control
+// exactly what "now" is.
+type Clock interface {
+	Now() time.Time
+}
+
+// clock is the Clock used throughout the package.
+var clock Clock = realClock{}
+
+// realClock is a Clock which returns the actual current time.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// FakeClock is a Clock for use in tests. Its time only changes when Advance
+// is called.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock returns a FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
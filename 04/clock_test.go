@@ -0,0 +1,114 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// useFakeClock replaces the package-level clock with a FakeClock for the
+// duration of the test.
+func useFakeClock(t *testing.T, now time.Time) *FakeClock {
+	t.Helper()
+
+	fc := NewFakeClock(now)
+	orig := clock
+	clock = fc
+	t.Cleanup(func() { clock = orig })
+
+	return fc
+}
+
+// recordingShopDB is a ShopModel which records the times it is asked to count
+// since.
+type recordingShopDB struct {
+	since []time.Time
+}
+
+func (r *recordingShopDB) CountCustomers(since time.Time) (int, error) {
+	r.since = append(r.since, since)
+	return 1000, nil
+}
+
+func (r *recordingShopDB) CountSales(since time.Time) (int, error) {
+	r.since = append(r.since, since)
+	return 333, nil
+}
+
+func TestFakeClock(t *testing.T) {
+	now := time.Date(2024, 3, 19, 19, 0, 0, 0, time.UTC)
+	fc := NewFakeClock(now)
+
+	if got := fc.Now(); !got.Equal(now) {
+		t.Fatalf("got %v; expected %v", got, now)
+	}
+
+	fc.Advance(90 * time.Minute)
+	if got, exp := fc.Now(), now.Add(90*time.Minute); !got.Equal(exp) {
+		t.Fatalf("got %v; expected %v", got, exp)
+	}
+}
+
+func TestCalculateSalesRateUsesClock(t *testing.T) {
+	now := time.Date(2024, 3, 19, 19, 0, 0, 0, time.UTC)
+	fc := useFakeClock(t, now)
+	r := &recordingShopDB{}
+
+	_, err := calculateSalesRate(r, SimpleRate{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fc.Advance(time.Hour)
+
+	_, err = calculateSalesRate(r, SimpleRate{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first call should count from 24 hours before the fake time, and
+	// the second call's window should have shifted forward by an hour.
+	exp := []time.Time{
+		now.Add(-24 * time.Hour),
+		now.Add(-24 * time.Hour),
+		now.Add(-23 * time.Hour),
+		now.Add(-23 * time.Hour),
+	}
+	if len(r.since) != len(exp) {
+		t.Fatalf("got %v; expected %v", r.since, exp)
+	}
+	for i := range exp {
+		if !r.since[i].Equal(exp[i]) {
+			t.Fatalf("call %d: got %v; expected %v", i, r.since[i], exp[i])
+		}
+	}
+}
+
+func TestSalesRateTrendUsesClock(t *testing.T) {
+	now := time.Date(2024, 3, 19, 19, 0, 0, 0, time.UTC)
+	fc := useFakeClock(t, now)
+	r := &recordingShopDB{}
+
+	fc.Advance(2 * time.Hour)
+
+	_, _, _, err := SalesRateTrend(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Both windows should be measured back from the fake time.
+	later := now.Add(2 * time.Hour)
+	exp := []time.Time{
+		later.Add(-24 * time.Hour),
+		later.Add(-24 * time.Hour),
+		later.Add(-48 * time.Hour),
+		later.Add(-48 * time.Hour),
+	}
+	if len(r.since) != len(exp) {
+		t.Fatalf("got %v; expected %v", r.since, exp)
+	}
+	for i := range exp {
+		if !r.since[i].Equal(exp[i]) {
+			t.Fatalf("call %d: got %v; expected %v", i, r.since[i], exp[i])
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import "math"
+
+// wilsonZ is the z-score for a 95% confidence level.
+const wilsonZ = 1.96
+
+// calculateSalesRateCI returns the sales rate along with a 95% confidence
+// interval for it, treating the rate as the proportion of customers who
+// made a sale.
+//
+// The interval is the Wilson score interval. Unlike the simpler normal
+// approximation (rate ± z·√(rate·(1-rate)/n)), it stays within [0, 1] and
+// does not collapse to zero width when the rate is exactly 0 or 1, which
+// makes it reliable for the small numbers of customers that a short time
+// window often has.
+//
+// Because the rate is a proportion, it is capped at 1 if there are more
+// sales than customers. With no customers there is nothing to estimate, so
+// all three values are 0.
+func calculateSalesRateCI(sales, customers int) (rate, low, high float64) {
+	if customers <= 0 {
+		return 0, 0, 0
+	}
+
+	n := float64(customers)
+	p := math.Min(math.Max(float64(sales)/n, 0), 1)
+	z2 := wilsonZ * wilsonZ
+
+	denom := 1 + z2/n
+	center := (p + z2/(2*n)) / denom
+	margin := wilsonZ * math.Sqrt(p*(1-p)/n+z2/(4*n*n)) / denom
+
+	return p, math.Max(center-margin, 0), math.Min(center+margin, 1)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalculateSalesRateCI(t *testing.T) {
+	// The expected intervals are published reference values for the 95%
+	// Wilson score interval.
+	tests := []struct {
+		name             string
+		sales, customers int
+		rate, low, high  float64
+	}{
+		{"1 of 10", 1, 10, 0.1, 0.0179, 0.4042},
+		{"0 of 10", 0, 10, 0, 0, 0.2775},
+		{"10 of 10", 10, 10, 1, 0.7225, 1},
+		{"50 of 100", 50, 100, 0.5, 0.4038, 0.5962},
+		{"no customers", 3, 0, 0, 0, 0},
+	}
+
+	const tolerance = 0.0001
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, low, high := calculateSalesRateCI(tt.sales, tt.customers)
+
+			if math.Abs(rate-tt.rate) > tolerance ||
+				math.Abs(low-tt.low) > tolerance ||
+				math.Abs(high-tt.high) > tolerance {
+				t.Fatalf("got (%.4f, %.4f, %.4f); expected (%.4f, %.4f, %.4f)",
+					rate, low, high, tt.rate, tt.low, tt.high)
+			}
+		})
+	}
+}
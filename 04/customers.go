@@ -0,0 +1,54 @@
+package main
+
+import (
+	"iter"
+	"time"
+)
+
+// Customer is a single row of the customers table.
+type Customer struct {
+	ID        int
+	Segment   string
+	Timestamp time.Time
+}
+
+// IterCustomers returns an iterator over the customers since the given time,
+// in ID order, for use with a range loop:
+//
+//	for c, err := range sdb.IterCustomers(since) {
+//		...
+//	}
+//
+// Rows are read one at a time as the loop asks for them, rather than all
+// being loaded into a slice first. If the loop stops early, with break or
+// return, the underlying *sql.Rows is still closed, because the iterator
+// function returns as soon as yield reports that no more values are wanted,
+// which runs its deferred Close. An error is yielded once, and ends the
+// iteration.
+func (sdb *ShopDB) IterCustomers(since time.Time) iter.Seq2[Customer, error] {
+	return func(yield func(Customer, error) bool) {
+		rows, err := sdb.DB().Query("SELECT id, segment, timestamp FROM customers WHERE timestamp > $1 ORDER BY id", since)
+		if err != nil {
+			yield(Customer{}, err)
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var c Customer
+			err = scan(rows, &c.ID, &c.Segment, &c.Timestamp)
+			if err != nil {
+				yield(Customer{}, err)
+				return
+			}
+			if !yield(c, nil) {
+				return
+			}
+		}
+
+		err = rows.Err()
+		if err != nil {
+			yield(Customer{}, err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func seedIterCustomers(t *testing.T) *ShopDB {
+	t.Helper()
+
+	db := openTestDB(t)
+	recent := time.Now().Add(-time.Hour)
+	err := SeedCustomers(db, []CustomerRow{
+		{ID: 1, Segment: "retail", Timestamp: recent},
+		{ID: 2, Segment: "wholesale", Timestamp: recent.Add(-72 * time.Hour)},
+		{ID: 3, Segment: "retail", Timestamp: recent},
+		{ID: 4, Segment: "online", Timestamp: recent},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &ShopDB{db: db}
+}
+
+func TestIterCustomers(t *testing.T) {
+	sdb := seedIterCustomers(t)
+
+	var ids []int
+	for c, err := range sdb.IterCustomers(time.Now().Add(-24 * time.Hour)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, c.ID)
+	}
+
+	exp := []int{1, 3, 4}
+	if len(ids) != len(exp) {
+		t.Fatalf("got %v; expected %v", ids, exp)
+	}
+	for i := range exp {
+		if ids[i] != exp[i] {
+			t.Fatalf("got %v; expected %v", ids, exp)
+		}
+	}
+}
+
+func TestIterCustomersBreak(t *testing.T) {
+	sdb := seedIterCustomers(t)
+
+	for c, err := range sdb.IterCustomers(time.Now().Add(-24 * time.Hour)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.ID != 1 || c.Segment != "retail" {
+			t.Fatalf("got %+v; expected customer 1 in retail", c)
+		}
+		break
+	}
+
+	// An open *sql.Rows holds on to its connection, so if the rows were
+	// closed there are no connections in use.
+	if inUse := sdb.DB().Stats().InUse; inUse != 0 {
+		t.Fatalf("got %d connections in use; expected 0", inUse)
+	}
+
+	// The test database only allows one connection, so this would block
+	// if it were still held.
+	_, err := sdb.CountCustomers(time.Now().Add(-24 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIterCustomersError(t *testing.T) {
+	sdb := seedIterCustomers(t)
+	_, err := sdb.DB().Exec("DROP TABLE customers")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n := 0
+	for _, err := range sdb.IterCustomers(time.Now()) {
+		n++
+		if err == nil {
+			t.Fatal("expected an error; got nil")
+		}
+	}
+	if n != 1 {
+		t.Fatalf("got %d iterations; expected 1", n)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HumanDuration is a time.Duration which formats itself for people rather
+// than for parsing, such as "2h 15m" instead of "2h15m0s". Converting to it
+// is free, because it has the same underlying type:
+//
+//	fmt.Println(HumanDuration(24 * time.Hour)) // 24h
+type HumanDuration time.Duration
+
+// String renders the hours, minutes and seconds, leaving out any which are
+// zero. Fractions of a second are dropped, unless the whole duration is less
+// than a second, in which case it is formatted as usual (for example
+// "250ms"). A zero duration is "0s".
+func (d HumanDuration) String() string {
+	if d < 0 {
+		// Negating the most negative duration overflows back to itself, so
+		// the magnitude is converted to a uint64, where it always fits.
+		return "-" + humanDuration(uint64(-d))
+	}
+	return humanDuration(uint64(d))
+}
+
+// humanDuration formats a duration of u nanoseconds for HumanDuration.String.
+func humanDuration(u uint64) string {
+	if u < uint64(time.Second) {
+		return time.Duration(u).String()
+	}
+
+	h := u / uint64(time.Hour)
+	m := u % uint64(time.Hour) / uint64(time.Minute)
+	s := u % uint64(time.Minute) / uint64(time.Second)
+
+	var parts []string
+	for _, c := range []struct {
+		n    uint64
+		unit string
+	}{{h, "h"}, {m, "m"}, {s, "s"}} {
+		if c.n != 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", c.n, c.unit))
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestHumanDuration(t *testing.T) {
+	tests := []struct {
+		d   time.Duration
+		exp string
+	}{
+		{0, "0s"},
+		{250 * time.Millisecond, "250ms"},
+		{45 * time.Second, "45s"},
+		{45*time.Second + 900*time.Millisecond, "45s"},
+		{2*time.Hour + 15*time.Minute, "2h 15m"},
+		{time.Hour + 5*time.Second, "1h 5s"},
+		{25*time.Hour + 61*time.Second, "25h 1m 1s"},
+		{-90 * time.Second, "-1m 30s"},
+		{-250 * time.Millisecond, "-250ms"},
+		{math.MaxInt64, "2562047h 47m 16s"},
+		{math.MinInt64, "-2562047h 47m 16s"},
+	}
+
+	for _, tt := range tests {
+		if got := HumanDuration(tt.d).String(); got != tt.exp {
+			t.Fatalf("HumanDuration(%v): got %q; expected %q", tt.d, got, tt.exp)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// SalesError describes a failed ShopDB query. It satisfies both the error
+// interface and the fmt.Stringer interface, so it can be returned as an error
+// or passed anywhere a fmt.Stringer is accepted.
+type SalesError struct {
+	Op    string
+	Since time.Time
+	Err   error
+}
+
+// String renders the error on a single line, for example:
+//
+//	CountSales since 2024-03-18T19:00:00Z: connection refused
+func (e *SalesError) String() string {
+	return fmt.Sprintf("%s since %s: %v", e.Op, e.Since.Format(time.RFC3339), e.Err)
+}
+
+func (e *SalesError) Error() string {
+	return e.String()
+}
+
+func (e *SalesError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSalesError(t *testing.T) {
+	cause := errors.New("connection refused")
+	since := time.Date(2024, 3, 18, 19, 0, 0, 0, time.UTC)
+
+	err := &SalesError{Op: "CountSales", Since: since, Err: cause}
+
+	var s fmt.Stringer = err
+	exp := "CountSales since 2024-03-18T19:00:00Z: connection refused"
+	if s.String() != exp {
+		t.Fatalf("got %q; expected %q", s.String(), exp)
+	}
+	if err.Error() != exp {
+		t.Fatalf("got %q; expected %q", err.Error(), exp)
+	}
+
+	if errors.Unwrap(err) != cause {
+		t.Fatalf("got %v; expected %v", errors.Unwrap(err), cause)
+	}
+}
+
+func TestShopDBWrapsSalesError(t *testing.T) {
+	// Without a customers table the query fails.
+	db := openTestDB(t)
+	_, err := db.Exec("DROP TABLE customers")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	since := time.Now().Add(-24 * time.Hour)
+	_, err = (&ShopDB{db: db}).CountCustomers(since)
+
+	var se *SalesError
+	if !errors.As(err, &se) {
+		t.Fatalf("got %T; expected *SalesError", err)
+	}
+	if se.Op != "CountCustomers" || !se.Since.Equal(since) {
+		t.Fatalf("got %+v; expected Op CountCustomers since %v", se, since)
+	}
+	if se.Err == nil {
+		t.Fatal("expected the cause to be recorded")
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import "time"
+
+// EventType identifies what kind of thing an Event records.
+type EventType string
+
+const (
+	EventSale     EventType = "sale"
+	EventCustomer EventType = "customer"
+)
+
+// Event records a single sale or customer visit at a point in time.
+type Event struct {
+	Type EventType
+	At   time.Time
+}
+
+// EventRepo is an in-memory ShopModel which derives its counts from a log of
+// appended events. Because the counts are calculated from timestamps, it makes
+// a more realistic test double than a mock which returns hardcoded numbers.
+type EventRepo struct {
+	events []Event
+}
+
+// Check at compile time that *EventRepo satisfies the ShopModel interface.
+var _ ShopModel = (*EventRepo)(nil)
+
+// Append adds events to the log.
+func (r *EventRepo) Append(events ...Event) {
+	r.events = append(r.events, events...)
+}
+
+func (r *EventRepo) CountCustomers(since time.Time) (int, error) {
+	return r.count(EventCustomer, since), nil
+}
+
+func (r *EventRepo) CountSales(since time.Time) (int, error) {
+	return r.count(EventSale, since), nil
+}
+
+// count returns the number of events of the given type which happened after
+// since, mirroring the "timestamp > $1" condition used by ShopDB.
+func (r *EventRepo) count(typ EventType, since time.Time) int {
+	var n int
+	for _, e := range r.events {
+		if e.Type == typ && e.At.After(since) {
+			n++
+		}
+	}
+	return n
+}
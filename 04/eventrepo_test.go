@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventRepo(t *testing.T) {
+	now := time.Date(2024, 3, 19, 19, 0, 0, 0, time.UTC)
+	since := now.Add(-24 * time.Hour)
+
+	r := &EventRepo{}
+	r.Append(
+		// Inside the window.
+		Event{Type: EventSale, At: now.Add(-1 * time.Hour)},
+		Event{Type: EventSale, At: since.Add(time.Second)},
+		Event{Type: EventCustomer, At: now.Add(-2 * time.Hour)},
+		Event{Type: EventCustomer, At: now.Add(-3 * time.Hour)},
+		Event{Type: EventCustomer, At: now.Add(-4 * time.Hour)},
+		// Exactly on the boundary, which is excluded.
+		Event{Type: EventSale, At: since},
+		// Outside the window.
+		Event{Type: EventSale, At: since.Add(-time.Second)},
+		Event{Type: EventCustomer, At: now.Add(-48 * time.Hour)},
+	)
+
+	sales, err := r.CountSales(since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sales != 2 {
+		t.Fatalf("got %v sales; expected %v", sales, 2)
+	}
+
+	customers, err := r.CountCustomers(since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if customers != 3 {
+		t.Fatalf("got %v customers; expected %v", customers, 3)
+	}
+}
@@ -0,0 +1,23 @@
+package main
+
+import "time"
+
+// ShopModelFuncs adapts a pair of plain functions to the ShopModel interface.
+// This is the same idea as http.HandlerFunc in the standard library: any
+// function with the right signature can be turned into a value that
+// satisfies an interface, without declaring a new type for it. It is handy
+// in tests, where the behavior can be written inline as closures.
+type ShopModelFuncs struct {
+	CountSalesFn     func(time.Time) (int, error)
+	CountCustomersFn func(time.Time) (int, error)
+}
+
+var _ ShopModel = ShopModelFuncs{}
+
+func (f ShopModelFuncs) CountSales(since time.Time) (int, error) {
+	return f.CountSalesFn(since)
+}
+
+func (f ShopModelFuncs) CountCustomers(since time.Time) (int, error) {
+	return f.CountCustomersFn(since)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestShopModelFuncs(t *testing.T) {
+	var calls []time.Time
+	sm := ShopModelFuncs{
+		CountSalesFn: func(since time.Time) (int, error) {
+			calls = append(calls, since)
+			return 50, nil
+		},
+		CountCustomersFn: func(since time.Time) (int, error) {
+			calls = append(calls, since)
+			return 200, nil
+		},
+	}
+
+	sr, err := calculateSalesRate(sm, SimpleRate{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := "0.25"; sr != exp {
+		t.Fatalf("got %v; expected %v", sr, exp)
+	}
+	if len(calls) != 2 || !calls[0].Equal(calls[1]) {
+		t.Fatalf("got calls %v; expected two calls with the same since time", calls)
+	}
+}
+
+func TestShopModelFuncsError(t *testing.T) {
+	errDown := errors.New("database is down")
+	sm := ShopModelFuncs{
+		CountSalesFn: func(time.Time) (int, error) {
+			return 0, errDown
+		},
+		CountCustomersFn: func(time.Time) (int, error) {
+			return 10, nil
+		},
+	}
+
+	_, err := calculateSalesRate(sm, SimpleRate{})
+	if !errors.Is(err, errDown) {
+		t.Fatalf("got %v; expected %v", err, errDown)
+	}
+}
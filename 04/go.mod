@@ -1,5 +1,9 @@
 module interfaces_04
 
-go 1.22.1
+go 1.23
 
-require github.com/lib/pq v1.10.9
+require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
+	github.com/lib/pq v1.10.9
+	github.com/mattn/go-sqlite3 v1.14.52
+)
@@ -19,7 +19,7 @@ func TestCalculateSalesRate(t *testing.T) {
 	// Initialize the mock.
 	m := &MockShopDB{}
 	// Pass the mock to the calculateSalesRate() function.
-	sr, err := calculateSalesRate(m)
+	sr, err := calculateSalesRate(m, SimpleRate{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -31,3 +31,21 @@ func TestCalculateSalesRate(t *testing.T) {
 		t.Fatalf("got %v; expected %v", sr, exp)
 	}
 }
+
+func TestFormatRate(t *testing.T) {
+	tests := []struct {
+		decimals int
+		exp      string
+	}{
+		{0, "0"},
+		{2, "0.33"},
+		{4, "0.3330"},
+	}
+
+	for _, tt := range tests {
+		got := FormatRate(0.333, tt.decimals)
+		if got != tt.exp {
+			t.Fatalf("FormatRate(0.333, %d): got %v; expected %v", tt.decimals, got, tt.exp)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import "time"
+
+// CountPurchasingCustomers returns the number of different customers who
+// made at least one sale since the given time. Unlike CountSales, a customer
+// who bought several times is only counted once. Like CountCustomers, sales
+// with no timestamp are included if the ShopDB was created with the
+// WithNullTimestamps option, so that the purchase rate compares like with
+// like.
+func (sdb *ShopDB) CountPurchasingCustomers(since time.Time) (int, error) {
+	if sdb.includeNullTimestamps {
+		return sdb.count("CountPurchasingCustomers", countPurchasingCustomersWithNullsQuery, since)
+	}
+	return sdb.count("CountPurchasingCustomers", countPurchasingCustomersQuery, since)
+}
+
+// PurchaseModel is implemented by anything which can count both the
+// customers and the customers who made a purchase. ShopDB satisfies it.
+type PurchaseModel interface {
+	CountCustomers(time.Time) (int, error)
+	CountPurchasingCustomers(time.Time) (int, error)
+}
+
+var _ PurchaseModel = (*ShopDB)(nil)
+
+// calculatePurchaseRate returns the number of customers who made a purchase
+// in the last 24 hours, per new customer in that time. Where the sales rate
+// counts every sale, this counts each purchasing customer once.
+//
+// It is not a fraction of the new customers, though: customers who joined
+// before the window can still buy during it, so the rate can be more than 1.
+// For example, if 1 customer joined and 3 older customers made a purchase,
+// the rate is 3.00.
+func calculatePurchaseRate(pm PurchaseModel) (string, error) {
+	since := clock.Now().Add(-24 * time.Hour)
+
+	purchasing, err := pm.CountPurchasingCustomers(since)
+	if err != nil {
+		return "", err
+	}
+
+	customers, err := pm.CountCustomers(since)
+	if err != nil {
+		return "", err
+	}
+
+	return FormatRate(rate(purchasing, customers), defaultRateDecimals), nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalculatePurchaseRate(t *testing.T) {
+	db := openTestDB(t)
+
+	now := time.Now()
+	recent := now.Add(-1 * time.Hour)
+	old := now.Add(-48 * time.Hour)
+
+	err := SeedCustomers(db, []CustomerRow{
+		{ID: 1, Timestamp: recent},
+		{ID: 2, Timestamp: recent},
+		{ID: 3, Timestamp: recent},
+		{ID: 4, Timestamp: recent},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Customer 1 buys three times, customer 2 once, and customer 3 only
+	// bought outside the window.
+	err = SeedSales(db, []SaleRow{
+		{CustomerID: 1, Timestamp: recent},
+		{CustomerID: 1, Timestamp: recent},
+		{CustomerID: 1, Timestamp: recent},
+		{CustomerID: 2, Timestamp: recent},
+		{CustomerID: 3, Timestamp: old},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sdb := &ShopDB{db: db}
+
+	purchasing, err := sdb.CountPurchasingCustomers(now.Add(-24 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if purchasing != 2 {
+		t.Fatalf("got %d purchasing customers; expected 2", purchasing)
+	}
+
+	pr, err := calculatePurchaseRate(sdb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := "0.50"; pr != exp {
+		t.Fatalf("got %v; expected %v", pr, exp)
+	}
+}
+
+func TestCalculatePurchaseRateWithNullTimestamps(t *testing.T) {
+	db := openTestDB(t)
+
+	now := time.Now()
+	recent := now.Add(-1 * time.Hour)
+
+	err := SeedCustomers(db, []CustomerRow{
+		{ID: 1, Timestamp: recent},
+		{ID: 3, Timestamp: recent},
+		{ID: 4, Timestamp: recent},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Customer 2, and their only sale, have no timestamp.
+	_, err = db.Exec(`
+INSERT INTO customers (id, timestamp) VALUES (2, NULL);
+INSERT INTO sales (customer_id, timestamp) VALUES (1, $1), (2, NULL);
+`, recent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		includeNulls bool
+		purchasing   int
+		rate         string
+	}{
+		{false, 1, "0.33"},
+		{true, 2, "0.50"},
+	}
+
+	for _, tt := range tests {
+		sdb := &ShopDB{db: db, includeNullTimestamps: tt.includeNulls}
+
+		purchasing, err := sdb.CountPurchasingCustomers(now.Add(-24 * time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if purchasing != tt.purchasing {
+			t.Fatalf("WithNullTimestamps=%v: got %d purchasing customers; expected %d",
+				tt.includeNulls, purchasing, tt.purchasing)
+		}
+
+		pr, err := calculatePurchaseRate(sdb)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pr != tt.rate {
+			t.Fatalf("WithNullTimestamps=%v: got %v; expected %v", tt.includeNulls, pr, tt.rate)
+		}
+	}
+}
+
+func TestCalculatePurchaseRateOlderCustomers(t *testing.T) {
+	db := openTestDB(t)
+
+	now := time.Now()
+	recent := now.Add(-1 * time.Hour)
+	old := now.Add(-48 * time.Hour)
+
+	err := SeedCustomers(db, []CustomerRow{
+		{ID: 1, Timestamp: recent},
+		{ID: 2, Timestamp: old},
+		{ID: 3, Timestamp: old},
+		{ID: 4, Timestamp: old},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the older customers buy in the window, so there are more
+	// purchasing customers than new ones.
+	err = SeedSales(db, []SaleRow{
+		{CustomerID: 2, Timestamp: recent},
+		{CustomerID: 3, Timestamp: recent},
+		{CustomerID: 4, Timestamp: recent},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pr, err := calculatePurchaseRate(&ShopDB{db: db})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := "3.00"; pr != exp {
+		t.Fatalf("got %v; expected %v", pr, exp)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Querier is the one method that the count queries need from a database.
+// *sql.DB, *sql.Tx and *sql.Conn all satisfy it, so depending on Querier
+// rather than on *sql.DB means a count can just as easily run inside a
+// transaction, or against a fake which records what it was asked to do.
+type Querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+var (
+	_ Querier = (*sql.DB)(nil)
+	_ Querier = (*sql.Tx)(nil)
+	_ Querier = (*sql.Conn)(nil)
+)
+
+// CountCustomersWith counts the customers since the given time using q.
+func CountCustomersWith(ctx context.Context, q Querier, since time.Time) (int, error) {
+	return countWith(ctx, q, "CountCustomers", countCustomersQuery, since)
+}
+
+// CountSalesWith counts the sales since the given time using q.
+func CountSalesWith(ctx context.Context, q Querier, since time.Time) (int, error) {
+	return countWith(ctx, q, "CountSales", countSalesQuery, since)
+}
+
+func countWith(ctx context.Context, q Querier, op, query string, since time.Time) (int, error) {
+	return scanCount(op, q.QueryRowContext(ctx, query, since), since)
+}
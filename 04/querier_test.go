@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+)
+
+// recordingQuerier is a Querier which records every query and its arguments
+// before passing it on. A *sql.Row can only come from a real database, so
+// the queries are still run by the wrapped Querier.
+type recordingQuerier struct {
+	Querier
+	queries []string
+	args    [][]interface{}
+}
+
+func (r *recordingQuerier) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	r.queries = append(r.queries, query)
+	r.args = append(r.args, args)
+	return r.Querier.QueryRowContext(ctx, query, args...)
+}
+
+func TestCountWithQuerier(t *testing.T) {
+	db := openTestDB(t)
+
+	now := time.Now()
+	err := SeedSales(db, []SaleRow{{Timestamp: now}, {Timestamp: now}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = SeedCustomers(db, []CustomerRow{{ID: 1, Timestamp: now}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := &recordingQuerier{Querier: db}
+	since := now.Add(-time.Hour)
+
+	sales, err := CountSalesWith(context.Background(), q, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	customers, err := CountCustomersWith(context.Background(), q, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sales != 2 || customers != 1 {
+		t.Fatalf("got %d sales and %d customers; expected 2 and 1", sales, customers)
+	}
+
+	expQueries := []string{countSalesQuery, countCustomersQuery}
+	if len(q.queries) != len(expQueries) {
+		t.Fatalf("got queries %q; expected %q", q.queries, expQueries)
+	}
+	for i, exp := range expQueries {
+		if q.queries[i] != exp {
+			t.Fatalf("query %d: got %q; expected %q", i, q.queries[i], exp)
+		}
+		if len(q.args[i]) != 1 || q.args[i][0] != since {
+			t.Fatalf("query %d: got args %v; expected [%v]", i, q.args[i], since)
+		}
+	}
+}
+
+func TestCountWithTx(t *testing.T) {
+	db := openTestDB(t)
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("INSERT INTO sales (timestamp) VALUES ($1)", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The uncommitted sale is visible inside the transaction.
+	sales, err := CountSalesWith(context.Background(), tx, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sales != 1 {
+		t.Fatalf("got %d; expected 1", sales)
+	}
+}
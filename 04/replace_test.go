@@ -0,0 +1,127 @@
+package main
+
+import (
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+)
+
+// openSalesDB opens a test database containing n recent sales.
+func openSalesDB(t *testing.T, n int) *sql.DB {
+	t.Helper()
+
+	db := openTestDB(t)
+	rows := make([]SaleRow, n)
+	for i := range rows {
+		rows[i].Timestamp = time.Now()
+	}
+	err := SeedSales(db, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestShopDBReplace(t *testing.T) {
+	// newShopDB prepares the count statements against the first database.
+	sdb, err := newShopDB(openSalesDB(t, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	since := time.Now().Add(-time.Hour)
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				// The databases alternate between 1 and 2 sales, so
+				// it's possible to tell that a count came from one of
+				// them, and none of them may fail even though each old
+				// database is closed as soon as it has been replaced.
+				sales, err := sdb.CountSales(since)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if sales != 1 && sales != 2 {
+					t.Errorf("got %d sales; expected 1 or 2", sales)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 20; i++ {
+		next := openSalesDB(t, 2-i%2)
+		old, err := sdb.Replace(next)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if old == next {
+			t.Fatalf("swap %d: Replace returned the new database", i)
+		}
+		err = old.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	close(stop)
+	wg.Wait()
+
+	// The statements were prepared again for the last database, which has
+	// one sale.
+	if sdb.salesStmt == nil || sdb.customersStmt == nil {
+		t.Fatal("expected the count statements to have been prepared again")
+	}
+	sales, err := sdb.CountSales(since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sales != 1 {
+		t.Fatalf("got %d sales; expected 1", sales)
+	}
+}
+
+func TestShopDBReplacePrepareError(t *testing.T) {
+	db := openSalesDB(t, 1)
+	sdb, err := newShopDB(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A database without the shop tables can't have the count statements
+	// prepared against it.
+	empty, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer empty.Close()
+
+	old, err := sdb.Replace(empty)
+	if err == nil || old != nil {
+		t.Fatalf("got (%v, %v); expected (nil, an error)", old, err)
+	}
+
+	// The ShopDB carries on with the original database.
+	if sdb.DB() != db {
+		t.Fatal("expected the original database to still be in use")
+	}
+	sales, err := sdb.CountSales(time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sales != 1 {
+		t.Fatalf("got %d sales; expected 1", sales)
+	}
+}
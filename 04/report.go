@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SalesReport holds the figures behind a sales rate calculation.
+type SalesReport struct {
+	Window    time.Duration
+	Sales     int
+	Customers int
+	Rate      float64
+}
+
+// ReportRenderer is implemented by anything which can turn a SalesReport into
+// a string. Code which emits reports can depend on this interface, and the
+// output format can be chosen by passing in a different implementation.
+type ReportRenderer interface {
+	Render(SalesReport) (string, error)
+}
+
+// TextRenderer renders a SalesReport as human-readable lines of text.
+type TextRenderer struct{}
+
+func (TextRenderer) Render(r SalesReport) (string, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Window: %s\n", HumanDuration(r.Window))
+	fmt.Fprintf(&sb, "Sales: %d\n", r.Sales)
+	fmt.Fprintf(&sb, "Customers: %d\n", r.Customers)
+	fmt.Fprintf(&sb, "Rate: %s\n", FormatRate(r.Rate, defaultRateDecimals))
+	return sb.String(), nil
+}
+
+// JSONRenderer renders a SalesReport as a JSON object.
+type JSONRenderer struct{}
+
+func (JSONRenderer) Render(r SalesReport) (string, error) {
+	js, err := json.Marshal(struct {
+		Window    string  `json:"window"`
+		Sales     int     `json:"sales"`
+		Customers int     `json:"customers"`
+		Rate      float64 `json:"rate"`
+	}{
+		Window:    r.Window.String(),
+		Sales:     r.Sales,
+		Customers: r.Customers,
+		Rate:      r.Rate,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(js), nil
+}
+
+// PrometheusText renders the report in the Prometheus text exposition format,
+// as three gauges, so that it can be scraped by a metrics system.
+func (r SalesReport) PrometheusText() string {
+	var sb strings.Builder
+
+	gauge := func(name, help string, value float64) {
+		fmt.Fprintf(&sb, "# HELP %s %s\n", name, help)
+		fmt.Fprintf(&sb, "# TYPE %s gauge\n", name)
+		fmt.Fprintf(&sb, "%s %s\n", name, strconv.FormatFloat(value, 'g', -1, 64))
+	}
+
+	gauge("sales_total", "Number of sales in the report window.", float64(r.Sales))
+	gauge("customers_total", "Number of customers in the report window.", float64(r.Customers))
+	gauge("sales_rate", "Sales per customer in the report window.", r.Rate)
+
+	return sb.String()
+}
+
+// ConversionPercent returns Sales / Customers * 100 as a percentage string,
+// rounded half-up to the given number of decimal places (for example
+// "33.30%"). If there were no customers it returns "0%".
+//
+// Rounding float64 values is surprisingly error-prone: 1.005 is actually
+// stored as 1.00499999999999989..., so rounding it to two places gives 1.00
+// rather than 1.01. To avoid this, the calculation is done exactly with
+// integers instead.
+func (r SalesReport) ConversionPercent(decimals int) string {
+	if r.Customers == 0 {
+		return "0%"
+	}
+	if decimals < 0 {
+		decimals = 0
+	}
+
+	// The percentage scaled up by 10^decimals is
+	//
+	//	sales * 100 * 10^decimals / customers
+	//
+	// and adding half of the divisor before dividing rounds half-up.
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	num := new(big.Int).Mul(big.NewInt(int64(r.Sales)*100), scale)
+	den := big.NewInt(int64(r.Customers))
+
+	num.Mul(num, big.NewInt(2)).Add(num, den)
+	den.Mul(den, big.NewInt(2))
+	scaled := num.Quo(num, den)
+
+	intPart, fracPart := new(big.Int).QuoRem(scaled, scale, new(big.Int))
+	if decimals == 0 {
+		return intPart.String() + "%"
+	}
+	return fmt.Sprintf("%s.%0*d%%", intPart, decimals, fracPart)
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testReport = SalesReport{
+	Window:    24 * time.Hour,
+	Sales:     333,
+	Customers: 1000,
+	Rate:      0.333,
+}
+
+func TestTextRenderer(t *testing.T) {
+	var rr ReportRenderer = TextRenderer{}
+
+	out, err := rr.Render(testReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := []string{
+		"Window: 24h",
+		"Sales: 333",
+		"Customers: 1000",
+		"Rate: 0.33",
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != len(exp) {
+		t.Fatalf("got %d lines; expected %d", len(lines), len(exp))
+	}
+	for i := range exp {
+		if lines[i] != exp[i] {
+			t.Fatalf("line %d: got %q; expected %q", i, lines[i], exp[i])
+		}
+	}
+}
+
+func TestJSONRenderer(t *testing.T) {
+	var rr ReportRenderer = JSONRenderer{}
+
+	out, err := rr.Render(testReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]interface{}
+	err = json.Unmarshal([]byte(out), &got)
+	if err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+
+	if got["window"] != "24h0m0s" {
+		t.Fatalf("got window %v; expected %v", got["window"], "24h0m0s")
+	}
+	if got["sales"] != float64(333) {
+		t.Fatalf("got sales %v; expected %v", got["sales"], 333)
+	}
+	if got["customers"] != float64(1000) {
+		t.Fatalf("got customers %v; expected %v", got["customers"], 1000)
+	}
+	if got["rate"] != 0.333 {
+		t.Fatalf("got rate %v; expected %v", got["rate"], 0.333)
+	}
+}
+
+func TestSalesReportPrometheusText(t *testing.T) {
+	out := testReport.PrometheusText()
+
+	// Parse the output following the text exposition rules: "# HELP" and
+	// "# TYPE" comment lines describe a metric, and every other non-empty
+	// line is a "name value" sample.
+	help := make(map[string]bool)
+	types := make(map[string]string)
+	samples := make(map[string]float64)
+
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) >= 3 && fields[0] == "#" && fields[1] == "HELP":
+			help[fields[2]] = true
+		case len(fields) == 4 && fields[0] == "#" && fields[1] == "TYPE":
+			types[fields[2]] = fields[3]
+		case len(fields) == 2:
+			if types[fields[0]] == "" {
+				t.Fatalf("sample %q appears before its TYPE line", line)
+			}
+			v, err := strconv.ParseFloat(fields[1], 64)
+			if err != nil {
+				t.Fatalf("sample %q has an invalid value: %v", line, err)
+			}
+			samples[fields[0]] = v
+		default:
+			t.Fatalf("unexpected line %q", line)
+		}
+	}
+
+	exp := map[string]float64{
+		"sales_total":     333,
+		"customers_total": 1000,
+		"sales_rate":      0.333,
+	}
+	for name, v := range exp {
+		if !help[name] {
+			t.Fatalf("%s: missing HELP line", name)
+		}
+		if types[name] != "gauge" {
+			t.Fatalf("%s: got type %q; expected %q", name, types[name], "gauge")
+		}
+		if samples[name] != v {
+			t.Fatalf("%s: got %v; expected %v", name, samples[name], v)
+		}
+	}
+}
+
+func TestSalesReportConversionPercent(t *testing.T) {
+	tests := []struct {
+		sales, customers int
+		decimals         int
+		exp              string
+	}{
+		{333, 1000, 2, "33.30%"},
+		{333, 1000, 0, "33%"},
+		{1, 3, 2, "33.33%"},
+		{2, 3, 1, "66.7%"},
+		{1, 8, 1, "12.5%"},
+		{1, 8, 0, "13%"},         // 12.5 rounds half-up to 13
+		{1, 200, 0, "1%"},        // 0.5 rounds half-up to 1
+		{201, 20000, 2, "1.01%"}, // 1.005 would round down as a float64
+		{1, 1000, 2, "0.10%"},
+		{5, 4, 1, "125.0%"},
+		{10, 0, 2, "0%"},
+	}
+
+	for _, tt := range tests {
+		r := SalesReport{Sales: tt.sales, Customers: tt.customers}
+		got := r.ConversionPercent(tt.decimals)
+		if got != tt.exp {
+			t.Fatalf("%d/%d to %d places: got %q; expected %q", tt.sales, tt.customers, tt.decimals, got, tt.exp)
+		}
+	}
+}
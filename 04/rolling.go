@@ -0,0 +1,85 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// sample is a single poll of the sales and customer counts.
+type sample struct {
+	at        time.Time
+	sales     int
+	customers int
+}
+
+// RollingRate keeps the samples from the last window of time, such as those
+// from polling a ShopModel every minute, and calculates the sales rate over
+// all of them together. Older samples are evicted as new ones are added.
+type RollingRate struct {
+	window  time.Duration
+	mu      sync.Mutex
+	samples []sample // oldest first
+}
+
+// NewRollingRate returns a RollingRate covering the given window.
+func NewRollingRate(window time.Duration) *RollingRate {
+	return &RollingRate{window: window}
+}
+
+// Add records a sample taken at the given time, and evicts any samples
+// which are now older than the window. Samples are expected to be added in
+// time order.
+func (rr *RollingRate) Add(at time.Time, sales, customers int) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	rr.samples = append(rr.samples, sample{at: at, sales: sales, customers: customers})
+
+	cutoff := at.Add(-rr.window)
+	i := 0
+	for i < len(rr.samples) && !rr.samples[i].at.After(cutoff) {
+		i++
+	}
+	rr.samples = rr.samples[i:]
+}
+
+// Poll counts the sales and customers since the last poll interval using sm,
+// and adds them as a sample taken now.
+func (rr *RollingRate) Poll(sm ShopModel, interval time.Duration) error {
+	now := clock.Now()
+	since := now.Add(-interval)
+
+	sales, err := sm.CountSales(since)
+	if err != nil {
+		return err
+	}
+
+	customers, err := sm.CountCustomers(since)
+	if err != nil {
+		return err
+	}
+
+	rr.Add(now, sales, customers)
+	return nil
+}
+
+// Rate returns the total sales divided by the total customers across the
+// samples in the window, or zero if there were no customers.
+func (rr *RollingRate) Rate() float64 {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	var sales, customers int
+	for _, s := range rr.samples {
+		sales += s.sales
+		customers += s.customers
+	}
+	return rate(sales, customers)
+}
+
+// Len returns the number of samples currently in the window.
+func (rr *RollingRate) Len() int {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	return len(rr.samples)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRollingRate(t *testing.T) {
+	start := time.Date(2024, 3, 19, 9, 0, 0, 0, time.UTC)
+	rr := NewRollingRate(time.Hour)
+
+	rr.Add(start, 10, 10)
+	rr.Add(start.Add(30*time.Minute), 1, 10)
+	if got, exp := rr.Rate(), 11.0/20; got != exp {
+		t.Fatalf("got %v; expected %v", got, exp)
+	}
+
+	// Exactly one window after the first sample, it falls out.
+	rr.Add(start.Add(time.Hour), 3, 10)
+	if got := rr.Len(); got != 2 {
+		t.Fatalf("got %d samples; expected 2", got)
+	}
+	if got, exp := rr.Rate(), 4.0/20; got != exp {
+		t.Fatalf("got %v; expected %v", got, exp)
+	}
+
+	// A long gap evicts everything but the newest sample.
+	rr.Add(start.Add(5*time.Hour), 0, 4)
+	if got := rr.Len(); got != 1 {
+		t.Fatalf("got %d samples; expected 1", got)
+	}
+	if got := rr.Rate(); got != 0 {
+		t.Fatalf("got %v; expected 0", got)
+	}
+}
+
+func TestRollingRateEmpty(t *testing.T) {
+	if got := NewRollingRate(time.Hour).Rate(); got != 0 {
+		t.Fatalf("got %v; expected 0", got)
+	}
+}
+
+func TestRollingRatePoll(t *testing.T) {
+	start := time.Date(2024, 3, 19, 9, 0, 0, 0, time.UTC)
+	fc := useFakeClock(t, start)
+
+	rr := NewRollingRate(2 * time.Minute)
+	sm := &MockShopDB{}
+
+	for i := 0; i < 3; i++ {
+		err := rr.Poll(sm, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fc.Advance(time.Minute)
+	}
+
+	// The polls were at 0, 1 and 2 minutes, so the first has been evicted.
+	if got := rr.Len(); got != 2 {
+		t.Fatalf("got %d samples; expected 2", got)
+	}
+	if got, exp := rr.Rate(), 0.333; got != exp {
+		t.Fatalf("got %v; expected %v", got, exp)
+	}
+}
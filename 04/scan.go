@@ -0,0 +1,30 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// RowScanner describes the one method that scanning code actually needs.
+// Both *sql.Row and *sql.Rows satisfy it, so accepting this interface rather
+// than a concrete type lets the same helper work with either (and with a fake
+// in tests).
+type RowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// ScanInto scans a single row into dest.
+func ScanInto(row *sql.Row, dest ...interface{}) error {
+	return scan(row, dest...)
+}
+
+// scan is the shared helper used for both single rows and result sets. It
+// annotates any error with the number of destinations, while keeping the
+// original error (such as sql.ErrNoRows) available to errors.Is.
+func scan(rs RowScanner, dest ...interface{}) error {
+	err := rs.Scan(dest...)
+	if err != nil {
+		return fmt.Errorf("scan into %d destination(s): %w", len(dest), err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+// fakeRowScanner records the destinations it was asked to scan into.
+type fakeRowScanner struct {
+	dest []interface{}
+	err  error
+}
+
+func (f *fakeRowScanner) Scan(dest ...interface{}) error {
+	f.dest = dest
+	return f.err
+}
+
+func TestScan(t *testing.T) {
+	var count int
+	var amount sql.NullFloat64
+
+	f := &fakeRowScanner{}
+	err := scan(f, &count, &amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(f.dest) != 2 {
+		t.Fatalf("got %d destinations; expected %d", len(f.dest), 2)
+	}
+	if f.dest[0] != &count {
+		t.Fatalf("dest[0]: got %v; expected %v", f.dest[0], &count)
+	}
+	if f.dest[1] != &amount {
+		t.Fatalf("dest[1]: got %v; expected %v", f.dest[1], &amount)
+	}
+}
+
+func TestScanError(t *testing.T) {
+	var count int
+
+	f := &fakeRowScanner{err: sql.ErrNoRows}
+	err := scan(f, &count)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("got %v; expected %v", err, sql.ErrNoRows)
+	}
+}
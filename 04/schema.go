@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// requiredColumns lists every table, and every column in each of them, that
+// the ShopDB queries depend on.
+var requiredColumns = []struct {
+	table   string
+	columns []string
+}{
+	{"customers", []string{"id", "segment", "timestamp"}},
+	{"sales", []string{"customer_id", "timestamp", "amount"}},
+	{"count_cache", []string{"name", "value", "updated_at"}},
+}
+
+// dialect identifies the kind of database behind a ShopDB, for the few
+// queries (such as listing a table's columns) which can't be written the same
+// way for all of them. The zero value is PostgreSQL, which is what NewShopDB
+// connects to.
+type dialect int
+
+const (
+	dialectPostgres dialect = iota
+	dialectSQLite
+)
+
+// VerifySchema checks that the tables and columns that the ShopDB queries
+// rely on exist, so that a misconfigured database is caught at startup
+// rather than on the first request. The returned error lists everything that
+// is missing.
+func (sdb *ShopDB) VerifySchema(ctx context.Context) error {
+	var missing []string
+
+	for _, req := range requiredColumns {
+		cols, err := sdb.tableColumns(ctx, req.table)
+		if err != nil {
+			return fmt.Errorf("verify schema: %w", err)
+		}
+
+		if len(cols) == 0 {
+			missing = append(missing, "table "+req.table)
+			continue
+		}
+		for _, col := range req.columns {
+			if !cols[col] {
+				missing = append(missing, "column "+req.table+"."+col)
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("verify schema: missing %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// tableColumns returns the set of column names in a table, which is empty if
+// the table does not exist. PostgreSQL (and most other databases) describe
+// their tables in information_schema, but sqlite does not have it, so its
+// pragma_table_info function is used instead. Only tables in the current
+// schema are looked at, so a table of the same name in another schema isn't
+// mistaken for ours.
+func (sdb *ShopDB) tableColumns(ctx context.Context, table string) (map[string]bool, error) {
+	db := sdb.DB()
+
+	query := "SELECT column_name FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = $1"
+	if sdb.dialect == dialectSQLite {
+		query = "SELECT name FROM pragma_table_info($1)"
+	}
+
+	rows, err := db.QueryContext(ctx, query, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		err := scan(rows, &name)
+		if err != nil {
+			return nil, err
+		}
+		cols[name] = true
+	}
+
+	return cols, rows.Err()
+}
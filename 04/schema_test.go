@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestVerifySchema(t *testing.T) {
+	sdb := &ShopDB{db: openTestDB(t), dialect: dialectSQLite}
+
+	err := sdb.VerifySchema(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifySchemaMissing(t *testing.T) {
+	db := openTestDB(t)
+	_, err := db.Exec(`
+DROP TABLE customers;
+CREATE TABLE customers (id INTEGER PRIMARY KEY, segment TEXT);
+DROP TABLE sales;
+DROP TABLE count_cache;
+CREATE TABLE count_cache (name TEXT PRIMARY KEY, value INTEGER);
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sdb := &ShopDB{db: db, dialect: dialectSQLite}
+
+	err = sdb.VerifySchema(context.Background())
+	if err == nil {
+		t.Fatal("expected an error; got nil")
+	}
+
+	for _, exp := range []string{"column customers.timestamp", "table sales", "column count_cache.updated_at"} {
+		if !strings.Contains(err.Error(), exp) {
+			t.Fatalf("error %q does not mention %q", err, exp)
+		}
+	}
+}
+
+func TestVerifySchemaInformationSchema(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	query := "SELECT column_name FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = $1"
+	for _, req := range requiredColumns {
+		rows := sqlmock.NewRows([]string{"column_name"})
+		for _, col := range req.columns {
+			rows.AddRow(col)
+		}
+		mock.ExpectQuery(query).WithArgs(req.table).WillReturnRows(rows)
+	}
+	sdb := &ShopDB{db: db}
+
+	err = sdb.VerifySchema(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifySchemaQueryError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// The error from the query must be returned as it is, rather than
+	// being hidden by trying some other query instead.
+	errDenied := errors.New("permission denied for schema information_schema")
+	mock.ExpectQuery("information_schema").WillReturnError(errDenied)
+	sdb := &ShopDB{db: db}
+
+	err = sdb.VerifySchema(context.Background())
+	if !errors.Is(err, errDenied) {
+		t.Fatalf("got %v; expected %v", err, errDenied)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifySchemaCanceled(t *testing.T) {
+	sdb := &ShopDB{db: openTestDB(t), dialect: dialectSQLite}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := sdb.VerifySchema(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v; expected %v", err, context.Canceled)
+	}
+}
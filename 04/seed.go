@@ -0,0 +1,61 @@
+package main
+
+import (
+	"database/sql"
+	"time"
+)
+
+// SaleRow is a single row of dummy data for the sales table.
+type SaleRow struct {
+	CustomerID int
+	Timestamp  time.Time
+	Amount     sql.NullFloat64
+}
+
+// CustomerRow is a single row of dummy data for the customers table.
+type CustomerRow struct {
+	ID        int
+	Segment   string
+	Timestamp time.Time
+}
+
+// SeedSales inserts the given rows into the sales table. It is intended for
+// scaffolding a test database with dummy data, so that calculateSalesRate()
+// can be exercised end-to-end without any setup scripts.
+func SeedSales(db *sql.DB, rows []SaleRow) error {
+	return seed(db, "INSERT INTO sales (customer_id, timestamp, amount) VALUES ($1, $2, $3)", len(rows), func(i int) []interface{} {
+		return []interface{}{rows[i].CustomerID, rows[i].Timestamp, rows[i].Amount}
+	})
+}
+
+// SeedCustomers inserts the given rows into the customers table.
+func SeedCustomers(db *sql.DB, rows []CustomerRow) error {
+	return seed(db, "INSERT INTO customers (id, segment, timestamp) VALUES ($1, $2, $3)", len(rows), func(i int) []interface{} {
+		return []interface{}{rows[i].ID, rows[i].Segment, rows[i].Timestamp}
+	})
+}
+
+// seed executes the insert query once per row inside a single transaction,
+// so that either all of the rows are inserted or none of them are.
+func seed(db *sql.DB, query string, n int, args func(i int) []interface{}) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for i := 0; i < n; i++ {
+		_, err = stmt.Exec(args(i)...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const testSchema = `
+CREATE TABLE customers (id INTEGER PRIMARY KEY, segment TEXT, timestamp DATETIME);
+CREATE TABLE sales (id INTEGER PRIMARY KEY, customer_id INTEGER, timestamp DATETIME, amount REAL);
+CREATE TABLE count_cache (name TEXT PRIMARY KEY, value INTEGER, updated_at DATETIME);
+`
+
+// openTestDB opens a fresh in-memory sqlite database with the shop schema.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every connection to ":memory:" gets its own database, so make sure
+	// that there is only ever one.
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(testSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func TestSeedAndCalculateSalesRate(t *testing.T) {
+	db := openTestDB(t)
+
+	now := time.Now()
+	recent := now.Add(-1 * time.Hour)
+	old := now.Add(-48 * time.Hour)
+
+	err := SeedSales(db, []SaleRow{
+		{Timestamp: recent},
+		{Timestamp: recent},
+		{Timestamp: recent},
+		{Timestamp: old},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = SeedCustomers(db, []CustomerRow{
+		{ID: 1, Timestamp: recent},
+		{ID: 2, Timestamp: recent},
+		{ID: 3, Timestamp: old},
+		{ID: 4, Timestamp: old},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sr, err := calculateSalesRate(&ShopDB{db: db}, SimpleRate{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the rows from the past 24 hours should be counted: 3 sales
+	// and 2 customers.
+	exp := "1.50"
+	if sr != exp {
+		t.Fatalf("got %v; expected %v", sr, exp)
+	}
+}
+
+func TestTotalSalesAmount(t *testing.T) {
+	db := openTestDB(t)
+
+	now := time.Now()
+	recent := now.Add(-1 * time.Hour)
+	old := now.Add(-48 * time.Hour)
+
+	amount := func(f float64) sql.NullFloat64 {
+		return sql.NullFloat64{Float64: f, Valid: true}
+	}
+
+	err := SeedSales(db, []SaleRow{
+		{Timestamp: recent, Amount: amount(10.50)},
+		{Timestamp: recent, Amount: sql.NullFloat64{}}, // voided
+		{Timestamp: recent, Amount: amount(4.25)},
+		{Timestamp: recent, Amount: sql.NullFloat64{}}, // voided
+		{Timestamp: old, Amount: amount(100)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sdb := &ShopDB{db: db}
+	total, err := sdb.TotalSalesAmount(now.Add(-24 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := 14.75
+	if total != exp {
+		t.Fatalf("got %v; expected %v", total, exp)
+	}
+}
+
+func TestSalesBySegment(t *testing.T) {
+	db := openTestDB(t)
+
+	now := time.Now()
+	recent := now.Add(-1 * time.Hour)
+	old := now.Add(-48 * time.Hour)
+
+	err := SeedCustomers(db, []CustomerRow{
+		{ID: 1, Segment: "retail", Timestamp: old},
+		{ID: 2, Segment: "retail", Timestamp: old},
+		{ID: 3, Segment: "wholesale", Timestamp: old},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sdb := &ShopDB{db: db}
+	since := now.Add(-24 * time.Hour)
+
+	// With no sales at all, an empty (but non-nil) map is returned.
+	segments, err := sdb.SalesBySegment(since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if segments == nil || len(segments) != 0 {
+		t.Fatalf("got %#v; expected an empty map", segments)
+	}
+
+	err = SeedSales(db, []SaleRow{
+		{CustomerID: 1, Timestamp: recent},
+		{CustomerID: 1, Timestamp: recent},
+		{CustomerID: 2, Timestamp: recent},
+		{CustomerID: 3, Timestamp: recent},
+		{CustomerID: 3, Timestamp: old},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	segments, err = sdb.SalesBySegment(since)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := map[string]int{"retail": 3, "wholesale": 1}
+	if len(segments) != len(exp) {
+		t.Fatalf("got %v; expected %v", segments, exp)
+	}
+	for segment, n := range exp {
+		if segments[segment] != n {
+			t.Fatalf("%s: got %v; expected %v", segment, segments[segment], n)
+		}
+	}
+}
+
+func TestCacheCount(t *testing.T) {
+	db := openTestDB(t)
+	sdb := &ShopDB{db: db}
+
+	first := time.Date(2024, 3, 19, 19, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+
+	err := sdb.CacheCount("sales", 333, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sdb.CacheCount("sales", 334, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rows, value int
+	var updatedAt time.Time
+	err = db.QueryRow("SELECT count(*), value, updated_at FROM count_cache WHERE name = $1", "sales").Scan(&rows, &value, &updatedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rows != 1 {
+		t.Fatalf("got %d rows; expected %d", rows, 1)
+	}
+	if value != 334 {
+		t.Fatalf("got %v; expected %v", value, 334)
+	}
+	if !updatedAt.Equal(second) {
+		t.Fatalf("got %v; expected %v", updatedAt, second)
+	}
+}
+
+func TestWithNullTimestamps(t *testing.T) {
+	db := openTestDB(t)
+
+	now := time.Now()
+	err := SeedCustomers(db, []CustomerRow{
+		{ID: 1, Timestamp: now},
+		{ID: 2, Timestamp: now.Add(-48 * time.Hour)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`
+INSERT INTO customers (id, timestamp) VALUES (3, NULL);
+INSERT INTO sales (timestamp) VALUES ($1), (NULL);
+`, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	since := now.Add(-24 * time.Hour)
+	tests := []struct {
+		includeNulls     bool
+		sales, customers int
+	}{
+		{false, 1, 1},
+		{true, 2, 2},
+	}
+
+	for _, tt := range tests {
+		sdb := &ShopDB{db: db, includeNullTimestamps: tt.includeNulls}
+
+		sales, err := sdb.CountSales(since)
+		if err != nil {
+			t.Fatal(err)
+		}
+		customers, err := sdb.CountCustomers(since)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if sales != tt.sales || customers != tt.customers {
+			t.Fatalf("WithNullTimestamps=%v: got %d sales and %d customers; expected %d and %d",
+				tt.includeNulls, sales, customers, tt.sales, tt.customers)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SegmentCounter is implemented by anything which can break the sales and
+// customer counts down by customer segment. ShopDB satisfies it.
+type SegmentCounter interface {
+	SalesBySegment(since time.Time) (map[string]int, error)
+	CustomersBySegment(since time.Time) (map[string]int, error)
+}
+
+var _ SegmentCounter = (*ShopDB)(nil)
+
+// calculateSegmentedRate calculates the sales rate of each segment named in
+// weights, and combines them into a weighted average. The weights don't have
+// to add up to 1, because they are normalized. A segment with no customers
+// has no rate to contribute, so it is left out and the remaining weights are
+// normalized without it; segments which aren't in weights are ignored.
+func calculateSegmentedRate(repo SegmentCounter, since time.Time, weights map[string]float64) (float64, error) {
+	sales, err := repo.SalesBySegment(since)
+	if err != nil {
+		return 0, err
+	}
+
+	customers, err := repo.CustomersBySegment(since)
+	if err != nil {
+		return 0, err
+	}
+
+	var weighted, totalWeight float64
+	for segment, w := range weights {
+		if w < 0 {
+			return 0, fmt.Errorf("segment %q has negative weight %v", segment, w)
+		}
+		if customers[segment] == 0 {
+			continue
+		}
+		weighted += w * rate(sales[segment], customers[segment])
+		totalWeight += w
+	}
+
+	if totalWeight == 0 {
+		return 0, errors.New("no weighted segment has any customers")
+	}
+	return weighted / totalWeight, nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// segmentShopDB is a fake SegmentCounter returning fixed counts.
+type segmentShopDB struct {
+	sales, customers map[string]int
+}
+
+func (s segmentShopDB) SalesBySegment(time.Time) (map[string]int, error) {
+	return s.sales, nil
+}
+
+func (s segmentShopDB) CustomersBySegment(time.Time) (map[string]int, error) {
+	return s.customers, nil
+}
+
+func TestCalculateSegmentedRate(t *testing.T) {
+	// retail has a rate of 0.5 and wholesale a rate of 0.1.
+	repo := segmentShopDB{
+		sales:     map[string]int{"retail": 50, "wholesale": 2},
+		customers: map[string]int{"retail": 100, "wholesale": 20},
+	}
+
+	tests := []struct {
+		name    string
+		weights map[string]float64
+		exp     float64
+	}{
+		{"equal", map[string]float64{"retail": 1, "wholesale": 1}, 0.3},
+		{"normalized", map[string]float64{"retail": 3, "wholesale": 1}, 0.4},
+		{"one segment", map[string]float64{"retail": 1}, 0.5},
+		{"missing segment", map[string]float64{"retail": 1, "online": 5}, 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := calculateSegmentedRate(repo, time.Now(), tt.weights)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(got-tt.exp) > 1e-9 {
+				t.Fatalf("got %v; expected %v", got, tt.exp)
+			}
+		})
+	}
+}
+
+func TestCalculateSegmentedRateErrors(t *testing.T) {
+	repo := segmentShopDB{
+		sales:     map[string]int{"retail": 5},
+		customers: map[string]int{"retail": 10},
+	}
+
+	tests := []struct {
+		name    string
+		weights map[string]float64
+	}{
+		{"no weights", nil},
+		{"no customers", map[string]float64{"online": 1}},
+		{"zero weight", map[string]float64{"retail": 0}},
+		{"negative weight", map[string]float64{"retail": -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := calculateSegmentedRate(repo, time.Now(), tt.weights)
+			if err == nil {
+				t.Fatal("expected an error; got nil")
+			}
+		})
+	}
+}
+
+func TestCalculateSegmentedRateShopDB(t *testing.T) {
+	db := openTestDB(t)
+
+	recent := time.Now().Add(-time.Hour)
+	err := SeedCustomers(db, []CustomerRow{
+		{ID: 1, Segment: "retail", Timestamp: recent},
+		{ID: 2, Segment: "retail", Timestamp: recent},
+		{ID: 3, Segment: "wholesale", Timestamp: recent},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = SeedSales(db, []SaleRow{
+		{CustomerID: 1, Timestamp: recent},
+		{CustomerID: 3, Timestamp: recent},
+		{CustomerID: 3, Timestamp: recent},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sdb := &ShopDB{db: db}
+	since := time.Now().Add(-24 * time.Hour)
+
+	customers, err := sdb.CustomersBySegment(since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if customers["retail"] != 2 || customers["wholesale"] != 1 {
+		t.Fatalf("got %v; expected 2 retail and 1 wholesale", customers)
+	}
+
+	// retail is 1/2 and wholesale is 2/1.
+	got, err := calculateSegmentedRate(sdb, since, map[string]float64{"retail": 1, "wholesale": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := 1.25; got != exp {
+		t.Fatalf("got %v; expected %v", got, exp)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import "time"
+
+// HourlyCounter is implemented by anything which can break the sales and
+// customer counts since a given time down into hourly buckets, keyed by the
+// start of each hour.
+type HourlyCounter interface {
+	SalesByHour(since time.Time) (map[time.Time]int, error)
+	CustomersByHour(since time.Time) (map[time.Time]int, error)
+}
+
+// SalesRateSeries returns the sales rate for every hourly bucket since the
+// given time. The two sets of buckets don't have to match: a bucket that is
+// missing from either map counts as zero, and a bucket with no customers
+// has a rate of zero rather than dividing by zero.
+func SalesRateSeries(repo HourlyCounter, since time.Time) (map[time.Time]float64, error) {
+	sales, err := repo.SalesByHour(since)
+	if err != nil {
+		return nil, err
+	}
+
+	customers, err := repo.CustomersByHour(since)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make(map[time.Time]float64, len(customers))
+	for hour, c := range customers {
+		series[hour] = rate(sales[hour], c)
+	}
+	for hour := range sales {
+		if _, ok := customers[hour]; !ok {
+			series[hour] = 0
+		}
+	}
+	return series, nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// hourlyShopDB is a fake HourlyCounter returning fixed buckets.
+type hourlyShopDB struct {
+	sales, customers map[time.Time]int
+	err              error
+}
+
+func (h hourlyShopDB) SalesByHour(time.Time) (map[time.Time]int, error) {
+	return h.sales, h.err
+}
+
+func (h hourlyShopDB) CustomersByHour(time.Time) (map[time.Time]int, error) {
+	return h.customers, nil
+}
+
+func TestSalesRateSeries(t *testing.T) {
+	h0 := time.Date(2024, 3, 19, 9, 0, 0, 0, time.UTC)
+	h1 := h0.Add(time.Hour)
+	h2 := h0.Add(2 * time.Hour)
+	h3 := h0.Add(3 * time.Hour)
+
+	repo := hourlyShopDB{
+		sales:     map[time.Time]int{h0: 2, h1: 3, h3: 4},
+		customers: map[time.Time]int{h0: 4, h1: 0, h2: 5},
+	}
+
+	got, err := SalesRateSeries(repo, h0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := map[time.Time]float64{
+		h0: 0.5, // both present
+		h1: 0,   // zero customers
+		h2: 0,   // no sales bucket
+		h3: 0,   // no customers bucket
+	}
+	if len(got) != len(exp) {
+		t.Fatalf("got %v; expected %v", got, exp)
+	}
+	for hour, rate := range exp {
+		if got[hour] != rate {
+			t.Fatalf("%s: got %v; expected %v", hour.Format(time.Kitchen), got[hour], rate)
+		}
+	}
+}
+
+func TestSalesRateSeriesError(t *testing.T) {
+	errDown := errors.New("database is down")
+
+	_, err := SalesRateSeries(hourlyShopDB{err: errDown}, time.Now())
+	if !errors.Is(err, errDown) {
+		t.Fatalf("got %v; expected %v", err, errDown)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// shutdownTimeout is how long RunServer waits for in-flight requests to
+// complete once its context has been cancelled.
+const shutdownTimeout = 5 * time.Second
+
+// salesRateHandler responds with the current sales rate. Like
+// calculateSalesRate(), it only depends on the ShopModel interface, so the
+// HTTP layer knows nothing about the database.
+func salesRateHandler(sm ShopModel) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sr, err := calculateSalesRate(sm, SimpleRate{})
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintln(w, sr)
+	})
+}
+
+// RunServer serves the sales rate handler at /sales-rate on addr until ctx is
+// cancelled, at which point the server is shut down gracefully.
+func RunServer(ctx context.Context, addr string, sm ShopModel) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return serve(ctx, ln, sm)
+}
+
+func serve(ctx context.Context, ln net.Listener, sm ShopModel) error {
+	mux := http.NewServeMux()
+	mux.Handle("/sales-rate", salesRateHandler(sm))
+
+	srv := &http.Server{Handler: mux}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	err := srv.Shutdown(shutdownCtx)
+	if err != nil {
+		return err
+	}
+
+	// Serve() always returns http.ErrServerClosed after a call to Shutdown(),
+	// so that is the expected outcome here rather than a failure.
+	err = <-errCh
+	if !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServe(t *testing.T) {
+	// Listen on an ephemeral port, so that we know the address before the
+	// server starts.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- serve(ctx, ln, &MockShopDB{})
+	}()
+
+	res, err := http.Get("http://" + ln.Addr().String() + "/sales-rate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d; expected %d", res.StatusCode, http.StatusOK)
+	}
+	exp := "0.33"
+	if strings.TrimSpace(string(body)) != exp {
+		t.Fatalf("got %q; expected %q", body, exp)
+	}
+
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("expected clean shutdown; got %v", err)
+		}
+	case <-time.After(shutdownTimeout):
+		t.Fatal("server did not shut down")
+	}
+}
+
+func TestSalesRateHandlerNoCustomers(t *testing.T) {
+	// With no customers in the window, the handler should still respond
+	// with a number rather than +Inf or NaN.
+	rec := httptest.NewRecorder()
+	salesRateHandler(zeroShopDB{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sales-rate", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d; expected %d", rec.Code, http.StatusOK)
+	}
+	exp := "0.00"
+	if got := strings.TrimSpace(rec.Body.String()); got != exp {
+		t.Fatalf("got %q; expected %q", got, exp)
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// defaultPingTimeout is how long NewShopDB waits for the database to respond
+// before giving up.
+const defaultPingTimeout = 5 * time.Second
+
+// shopConfig holds the settings which can be changed by passing Options to
+// NewShopDB. A zero value for maxOpenConns or connMaxLifetime leaves the
+// database/sql default (unlimited) in place.
+type shopConfig struct {
+	maxOpenConns    int
+	connMaxLifetime time.Duration
+	pingTimeout     time.Duration
+
+	includeNullTimestamps bool
+}
+
+// Option configures a ShopDB created by NewShopDB.
+type Option func(*shopConfig)
+
+// WithMaxOpenConns limits the number of open connections to the database.
+func WithMaxOpenConns(n int) Option {
+	return func(c *shopConfig) {
+		c.maxOpenConns = n
+	}
+}
+
+// WithConnMaxLifetime sets the maximum amount of time a connection may be
+// reused for.
+func WithConnMaxLifetime(d time.Duration) Option {
+	return func(c *shopConfig) {
+		c.connMaxLifetime = d
+	}
+}
+
+// WithPingTimeout sets how long NewShopDB waits for the initial ping. The
+// default is 5 seconds.
+func WithPingTimeout(d time.Duration) Option {
+	return func(c *shopConfig) {
+		c.pingTimeout = d
+	}
+}
+
+// WithNullTimestamps makes the counts include rows whose timestamp is NULL.
+// In SQL, NULL > $1 is neither true nor false, so by default those rows are
+// silently left out of every count. With this option, rows with an unknown
+// time are counted as recent instead.
+func WithNullTimestamps() Option {
+	return func(c *shopConfig) {
+		c.includeNullTimestamps = true
+	}
+}
+
+func newShopConfig(opts ...Option) shopConfig {
+	cfg := shopConfig{
+		pingTimeout: defaultPingTimeout,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// NewShopDB opens a connection pool to the PostgreSQL database at dsn,
+// applies any options, and checks that the database is reachable. If it does
+// not respond within the ping timeout an error is returned, rather than
+// blocking startup forever.
+func NewShopDB(dsn string, opts ...Option) (*ShopDB, error) {
+	// Opening the database via a pq.Connector (rather than sql.Open) means
+	// the context passed to PingContext is also used when dialing, so the
+	// timeout applies to establishing the connection too.
+	connector, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return newShopDB(sql.OpenDB(connector), opts...)
+}
+
+// newShopDB configures and pings an already opened *sql.DB, and prepares the
+// count statements. It is split out from NewShopDB so that tests can pass in
+// a database from another driver.
+func newShopDB(db *sql.DB, opts ...Option) (*ShopDB, error) {
+	cfg := newShopConfig(opts...)
+
+	if cfg.maxOpenConns > 0 {
+		db.SetMaxOpenConns(cfg.maxOpenConns)
+	}
+	if cfg.connMaxLifetime > 0 {
+		db.SetConnMaxLifetime(cfg.connMaxLifetime)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.pingTimeout)
+	defer cancel()
+
+	err := db.PingContext(ctx)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ping database (timeout %s): %w", cfg.pingTimeout, err)
+	}
+
+	sdb := &ShopDB{db: db, includeNullTimestamps: cfg.includeNullTimestamps}
+
+	sdb.salesStmt, sdb.customersStmt, err = prepareCounts(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return sdb, nil
+}
+
+// prepareCounts prepares the count statements against db.
+func prepareCounts(db *sql.DB) (salesStmt, customersStmt *sql.Stmt, err error) {
+	salesStmt, err = db.Prepare(countSalesQuery)
+	if err != nil {
+		return nil, nil, fmt.Errorf("prepare sales count: %w", err)
+	}
+
+	customersStmt, err = db.Prepare(countCustomersQuery)
+	if err != nil {
+		salesStmt.Close()
+		return nil, nil, fmt.Errorf("prepare customers count: %w", err)
+	}
+
+	return salesStmt, customersStmt, nil
+}
+
+// Close closes the prepared statements and then the underlying database.
+func (sdb *ShopDB) Close() error {
+	sdb.mu.RLock()
+	defer sdb.mu.RUnlock()
+
+	var errs []error
+	for _, stmt := range []*sql.Stmt{sdb.salesStmt, sdb.customersStmt} {
+		if stmt != nil {
+			errs = append(errs, stmt.Close())
+		}
+	}
+	errs = append(errs, sdb.db.Close())
+	return errors.Join(errs...)
+}
+
+// DB returns the current underlying database. If Replace is called
+// afterwards, the returned *sql.DB is the old database, so it shouldn't be
+// held on to for longer than it is needed.
+func (sdb *ShopDB) DB() *sql.DB {
+	sdb.mu.RLock()
+	defer sdb.mu.RUnlock()
+	return sdb.db
+}
+
+// Replace swaps the underlying database for newDB and returns the old one,
+// so that a long-running service can reconnect (for example, to rotate its
+// credentials) without restarting.
+//
+// The count statements are prepared against newDB before anything is
+// swapped, so if that fails an error is returned and the ShopDB carries on
+// using the old database. Replace waits for any counts which are already
+// running to finish, and every count after it uses newDB.
+//
+// Other queries (such as TotalSalesAmount, or any made on a *sql.DB from
+// DB()) which started before Replace may still be running on the old
+// database. The caller is responsible for closing the old database, but
+// should only do so once those queries have finished.
+func (sdb *ShopDB) Replace(newDB *sql.DB) (old *sql.DB, err error) {
+	salesStmt, customersStmt, err := prepareCounts(newDB)
+	if err != nil {
+		return nil, err
+	}
+
+	sdb.mu.Lock()
+	defer sdb.mu.Unlock()
+
+	// No count can be using the old statements while the lock is held, so
+	// they can be closed straight away.
+	for _, stmt := range []*sql.Stmt{sdb.salesStmt, sdb.customersStmt} {
+		if stmt != nil {
+			stmt.Close()
+		}
+	}
+
+	old = sdb.db
+	sdb.db = newDB
+	sdb.salesStmt, sdb.customersStmt = salesStmt, customersStmt
+	return old, nil
+}
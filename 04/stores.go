@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// calculateSalesRatesForStores calculates the sales rate over the past 24
+// hours for each of the stores, using at most concurrency goroutines at once.
+// The results are keyed by the store's index in the slice. A store which
+// fails doesn't stop the others: its error is collected (and ordered by store
+// index) and it is left out of the results.
+func calculateSalesRatesForStores(stores []ShopModel, concurrency int) (map[int]float64, []error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	since := clock.Now().Add(-24 * time.Hour)
+
+	type result struct {
+		i    int
+		rate float64
+		err  error
+	}
+
+	jobs := make(chan int)
+	results := make(chan result)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				r, err := salesRateSince(stores[i], since)
+				results <- result{i: i, rate: r, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		for i := range stores {
+			jobs <- i
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	rates := make(map[int]float64)
+	var failed []result
+	for r := range results {
+		if r.err != nil {
+			failed = append(failed, r)
+			continue
+		}
+		rates[r.i] = r.rate
+	}
+
+	sort.Slice(failed, func(a, b int) bool { return failed[a].i < failed[b].i })
+
+	var errs []error
+	for _, r := range failed {
+		errs = append(errs, fmt.Errorf("store %d: %w", r.i, r.err))
+	}
+	return rates, errs
+}
+
+// salesRateSince returns the sales rate since the given time, or zero if
+// there were no customers.
+func salesRateSince(sm ShopModel, since time.Time) (float64, error) {
+	sales, err := sm.CountSales(since)
+	if err != nil {
+		return 0, err
+	}
+
+	customers, err := sm.CountCustomers(since)
+	if err != nil {
+		return 0, err
+	}
+
+	return rate(sales, customers), nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fixedShopDB is a ShopModel which returns fixed counts, or an error.
+type fixedShopDB struct {
+	sales, customers int
+	err              error
+}
+
+func (f fixedShopDB) CountCustomers(_ time.Time) (int, error) {
+	return f.customers, f.err
+}
+
+func (f fixedShopDB) CountSales(_ time.Time) (int, error) {
+	return f.sales, f.err
+}
+
+func TestCalculateSalesRatesForStores(t *testing.T) {
+	errDown := errors.New("database is down")
+
+	stores := []ShopModel{
+		fixedShopDB{sales: 1, customers: 4},
+		fixedShopDB{err: errDown},
+		fixedShopDB{sales: 3, customers: 4},
+		fixedShopDB{err: errDown},
+		fixedShopDB{sales: 5, customers: 10},
+	}
+
+	rates, errs := calculateSalesRatesForStores(stores, 2)
+
+	exp := map[int]float64{0: 0.25, 2: 0.75, 4: 0.5}
+	if len(rates) != len(exp) {
+		t.Fatalf("got %v; expected %v", rates, exp)
+	}
+	for i, r := range exp {
+		if rates[i] != r {
+			t.Fatalf("store %d: got %v; expected %v", i, rates[i], r)
+		}
+	}
+
+	if len(errs) != 2 {
+		t.Fatalf("got %v; expected 2 errors", errs)
+	}
+	for n, i := range []string{"store 1", "store 3"} {
+		if !errors.Is(errs[n], errDown) || !strings.Contains(errs[n].Error(), i) {
+			t.Fatalf("error %d: got %v; expected %s to wrap %v", n, errs[n], i, errDown)
+		}
+	}
+}
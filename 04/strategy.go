@@ -0,0 +1,28 @@
+package main
+
+// RateStrategy is implemented by anything which can turn a number of sales
+// and a number of customers into a sales rate. Passing a different
+// RateStrategy to calculateSalesRate() changes how the rate is calculated,
+// without changing calculateSalesRate() itself.
+type RateStrategy interface {
+	Rate(sales, customers int) float64
+}
+
+// SimpleRate divides sales by customers. With zero customers the rate is
+// zero, as it is for rate() in trend.go, rather than the +Inf or NaN which
+// dividing a float64 by zero would give.
+type SimpleRate struct{}
+
+func (SimpleRate) Rate(sales, customers int) float64 {
+	return rate(sales, customers)
+}
+
+// SmoothedRate applies Laplace (add-one) smoothing, calculating
+// (sales + 1) / (customers + 2). This never divides by zero, and pulls the
+// rate for small numbers of customers towards 0.5 rather than letting a
+// handful of customers produce an extreme value.
+type SmoothedRate struct{}
+
+func (SmoothedRate) Rate(sales, customers int) float64 {
+	return float64(sales+1) / float64(customers+2)
+}
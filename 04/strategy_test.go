@@ -0,0 +1,70 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSimpleRate(t *testing.T) {
+	var rs RateStrategy = SimpleRate{}
+
+	if got := rs.Rate(333, 1000); got != 0.333 {
+		t.Fatalf("got %v; expected %v", got, 0.333)
+	}
+	if got := rs.Rate(5, 0); got != 0 {
+		t.Fatalf("got %v; expected %v", got, 0)
+	}
+	if got := rs.Rate(0, 0); got != 0 {
+		t.Fatalf("got %v; expected %v", got, 0)
+	}
+}
+
+func TestSmoothedRate(t *testing.T) {
+	var rs RateStrategy = SmoothedRate{}
+
+	tests := []struct {
+		sales, customers int
+		exp              float64
+	}{
+		{333, 1000, 334.0 / 1002.0},
+		{5, 0, 3},
+		{0, 0, 0.5},
+	}
+
+	for _, tt := range tests {
+		got := rs.Rate(tt.sales, tt.customers)
+		if math.Abs(got-tt.exp) > 1e-9 {
+			t.Fatalf("Rate(%d, %d): got %v; expected %v", tt.sales, tt.customers, got, tt.exp)
+		}
+	}
+}
+
+// zeroShopDB is a ShopModel with no sales and no customers.
+type zeroShopDB struct{}
+
+func (zeroShopDB) CountCustomers(_ time.Time) (int, error) { return 0, nil }
+func (zeroShopDB) CountSales(_ time.Time) (int, error)     { return 0, nil }
+
+func TestCalculateSalesRateStrategies(t *testing.T) {
+	tests := []struct {
+		name string
+		rs   RateStrategy
+		exp  string
+	}{
+		{"simple", SimpleRate{}, "0.00"},
+		{"smoothed", SmoothedRate{}, "0.50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sr, err := calculateSalesRate(zeroShopDB{}, tt.rs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sr != tt.exp {
+				t.Fatalf("got %v; expected %v", sr, tt.exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// RateResult is a single sales rate produced by StreamSalesRate, or the
+// error which stopped it from being calculated.
+type RateResult struct {
+	Rate float64
+	Err  error
+	At   time.Time
+}
+
+// StreamSalesRate calculates the sales rate over the previous 24 hours every
+// interval, and sends each result on the returned channel. It stops and
+// closes the channel once ctx is cancelled. An error doesn't stop the
+// stream; it is sent in a RateResult and the next tick tries again.
+func StreamSalesRate(ctx context.Context, sm ShopModel, interval time.Duration) <-chan RateResult {
+	results := make(chan RateResult)
+
+	go func() {
+		defer close(results)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			now := clock.Now()
+			r, err := salesRateSince(sm, now.Add(-24*time.Hour))
+
+			// Sending blocks until the receiver is ready, so it has to
+			// watch for cancellation too, or the goroutine could leak.
+			select {
+			case results <- RateResult{Rate: r, Err: err, At: now}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return results
+}
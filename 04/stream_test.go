@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStreamSalesRate(t *testing.T) {
+	errDown := errors.New("database is down")
+	calls := 0
+	sm := ShopModelFuncs{
+		CountSalesFn: func(time.Time) (int, error) {
+			calls++
+			if calls == 2 {
+				return 0, errDown
+			}
+			return 25, nil
+		},
+		CountCustomersFn: func(time.Time) (int, error) {
+			return 100, nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	results := StreamSalesRate(ctx, sm, time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		r := <-results
+		if i == 1 {
+			if !errors.Is(r.Err, errDown) {
+				t.Fatalf("result %d: got error %v; expected %v", i, r.Err, errDown)
+			}
+			continue
+		}
+		if r.Err != nil || r.Rate != 0.25 || r.At.IsZero() {
+			t.Fatalf("result %d: got %+v; expected a rate of 0.25", i, r)
+		}
+	}
+
+	cancel()
+
+	// Once cancelled, the channel must be closed. A result which was
+	// already in flight may still arrive first.
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-results:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("channel was not closed after cancelling")
+		}
+	}
+}
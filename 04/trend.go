@@ -0,0 +1,54 @@
+package main
+
+import "time"
+
+// SalesRateTrend calculates the sales rate for the last 24 hours (current)
+// and for the 24 hours before that (previous), along with the percentage
+// change between them. Like calculateSalesRate, it gets the current time from
+// the package-level clock.
+//
+// ShopModel can only count everything since a point in time, so the counts
+// for the previous day are found by counting since 48 hours ago and then
+// subtracting the counts for the current day. If the previous rate is zero
+// the percentage change is undefined, and deltaPct is reported as 0.
+func SalesRateTrend(sm ShopModel) (current, previous, deltaPct float64, err error) {
+	now := clock.Now()
+	dayAgo := now.Add(-24 * time.Hour)
+	twoDaysAgo := now.Add(-48 * time.Hour)
+
+	currentSales, err := sm.CountSales(dayAgo)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	currentCustomers, err := sm.CountCustomers(dayAgo)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	totalSales, err := sm.CountSales(twoDaysAgo)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	totalCustomers, err := sm.CountCustomers(twoDaysAgo)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	current = rate(currentSales, currentCustomers)
+	previous = rate(totalSales-currentSales, totalCustomers-currentCustomers)
+
+	if previous != 0 {
+		deltaPct = (current - previous) / previous * 100
+	}
+
+	return current, previous, deltaPct, nil
+}
+
+// rate returns sales divided by customers, or zero if there were no
+// customers.
+func rate(sales, customers int) float64 {
+	if customers == 0 {
+		return 0
+	}
+	return float64(sales) / float64(customers)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// windowShopDB is a fake ShopModel which returns different counts depending
+// on the time they are counted since.
+type windowShopDB struct {
+	sales     map[time.Time]int
+	customers map[time.Time]int
+}
+
+func (w *windowShopDB) CountCustomers(since time.Time) (int, error) {
+	return w.customers[since], nil
+}
+
+func (w *windowShopDB) CountSales(since time.Time) (int, error) {
+	return w.sales[since], nil
+}
+
+func TestSalesRateTrend(t *testing.T) {
+	now := time.Date(2024, 3, 19, 19, 0, 0, 0, time.UTC)
+	useFakeClock(t, now)
+	dayAgo := now.Add(-24 * time.Hour)
+	twoDaysAgo := now.Add(-48 * time.Hour)
+
+	tests := []struct {
+		name                        string
+		sm                          *windowShopDB
+		current, previous, deltaPct float64
+	}{
+		{
+			// Today: 30 sales / 100 customers. Yesterday: 20 / 100.
+			name: "increase",
+			sm: &windowShopDB{
+				sales:     map[time.Time]int{dayAgo: 30, twoDaysAgo: 50},
+				customers: map[time.Time]int{dayAgo: 100, twoDaysAgo: 200},
+			},
+			current:  0.3,
+			previous: 0.2,
+			deltaPct: 50,
+		},
+		{
+			// Today: 10 sales / 100 customers. Yesterday: 40 / 100.
+			name: "decrease",
+			sm: &windowShopDB{
+				sales:     map[time.Time]int{dayAgo: 10, twoDaysAgo: 50},
+				customers: map[time.Time]int{dayAgo: 100, twoDaysAgo: 200},
+			},
+			current:  0.1,
+			previous: 0.4,
+			deltaPct: -75,
+		},
+		{
+			// There were no customers yesterday, so the previous rate is
+			// zero and the percentage change cannot be calculated.
+			name: "no previous customers",
+			sm: &windowShopDB{
+				sales:     map[time.Time]int{dayAgo: 30, twoDaysAgo: 30},
+				customers: map[time.Time]int{dayAgo: 100, twoDaysAgo: 100},
+			},
+			current:  0.3,
+			previous: 0,
+			deltaPct: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, previous, deltaPct, err := SalesRateTrend(tt.sm)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, c := range []struct {
+				name     string
+				got, exp float64
+			}{
+				{"current", current, tt.current},
+				{"previous", previous, tt.previous},
+				{"deltaPct", deltaPct, tt.deltaPct},
+			} {
+				if math.Abs(c.got-c.exp) > 1e-9 {
+					t.Fatalf("%s: got %v; expected %v", c.name, c.got, c.exp)
+				}
+			}
+		})
+	}
+}
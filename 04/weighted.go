@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// EventLister is implemented by repositories which can return the individual
+// timestamped events behind their counts, such as EventRepo.
+type EventLister interface {
+	Events() ([]Event, error)
+}
+
+// Events returns a copy of the events in the log.
+func (r *EventRepo) Events() ([]Event, error) {
+	return append([]Event(nil), r.events...), nil
+}
+
+// calculateWeightedSalesRate calculates a sales rate in which recent events
+// count for more than older ones. Each event is given a weight which halves
+// for every halfLife that has elapsed since it happened:
+//
+//	w(e) = 2^(-(now - e.At) / halfLife)
+//
+// and the rate is the weighted number of sales divided by the weighted number
+// of customers:
+//
+//	rate = Σ w(sale) / Σ w(customer)
+//
+// Events which happen after now are ignored.
+func calculateWeightedSalesRate(repo EventLister, now time.Time, halfLife time.Duration) (float64, error) {
+	if halfLife <= 0 {
+		return 0, errors.New("half-life must be positive")
+	}
+
+	events, err := repo.Events()
+	if err != nil {
+		return 0, err
+	}
+
+	var sales, customers float64
+	for _, e := range events {
+		if e.At.After(now) {
+			continue
+		}
+
+		w := math.Exp2(-float64(now.Sub(e.At)) / float64(halfLife))
+		switch e.Type {
+		case EventSale:
+			sales += w
+		case EventCustomer:
+			customers += w
+		}
+	}
+
+	if customers == 0 {
+		return 0, errors.New("no customers")
+	}
+
+	return sales / customers, nil
+}
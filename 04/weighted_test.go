@@ -0,0 +1,99 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// unweightedRate calculates the plain sales rate over the past 24 hours from
+// the same repository, for comparison with the weighted rate.
+func unweightedRate(t *testing.T, r *EventRepo, now time.Time) float64 {
+	t.Helper()
+
+	since := now.Add(-24 * time.Hour)
+	sales, err := r.CountSales(since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	customers, err := r.CountCustomers(since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return float64(sales) / float64(customers)
+}
+
+func TestCalculateWeightedSalesRate(t *testing.T) {
+	now := time.Date(2024, 3, 19, 19, 0, 0, 0, time.UTC)
+	halfLife := 6 * time.Hour
+
+	tests := []struct {
+		name       string
+		events     []Event
+		weighted   float64
+		unweighted float64
+	}{
+		{
+			// When every event happens at the same time, every weight is
+			// the same and the two rates match.
+			name: "same age",
+			events: []Event{
+				{Type: EventSale, At: now.Add(-time.Hour)},
+				{Type: EventCustomer, At: now.Add(-time.Hour)},
+				{Type: EventCustomer, At: now.Add(-time.Hour)},
+			},
+			weighted:   0.5,
+			unweighted: 0.5,
+		},
+		{
+			// The older customer only counts as half a customer, so the
+			// weighted rate is higher: 2 / (1 + 0.5).
+			name: "older customer",
+			events: []Event{
+				{Type: EventSale, At: now},
+				{Type: EventSale, At: now},
+				{Type: EventCustomer, At: now},
+				{Type: EventCustomer, At: now.Add(-halfLife)},
+			},
+			weighted:   2 / 1.5,
+			unweighted: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &EventRepo{}
+			r.Append(tt.events...)
+
+			weighted, err := calculateWeightedSalesRate(r, now, halfLife)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(weighted-tt.weighted) > 1e-9 {
+				t.Fatalf("weighted: got %v; expected %v", weighted, tt.weighted)
+			}
+
+			unweighted := unweightedRate(t, r, now)
+			if math.Abs(unweighted-tt.unweighted) > 1e-9 {
+				t.Fatalf("unweighted: got %v; expected %v", unweighted, tt.unweighted)
+			}
+		})
+	}
+}
+
+func TestCalculateWeightedSalesRateErrors(t *testing.T) {
+	now := time.Date(2024, 3, 19, 19, 0, 0, 0, time.UTC)
+
+	r := &EventRepo{}
+	r.Append(Event{Type: EventSale, At: now})
+
+	_, err := calculateWeightedSalesRate(r, now, 0)
+	if err == nil {
+		t.Fatal("expected an error for a zero half-life; got nil")
+	}
+
+	_, err = calculateWeightedSalesRate(r, now, time.Hour)
+	if err == nil {
+		t.Fatal("expected an error when there are no customers; got nil")
+	}
+}
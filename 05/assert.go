@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Try runs f and recovers from any panic that happens inside it, returning
+// the panic as an error. It returns nil if f completes normally.
+//
+// It's a teaching tool for the defer/recover pattern: wrapping code which
+// uses raw, single-value type assertions like person["age"].(int) means a
+// failed assertion is reported as an error instead of crashing the program.
+func Try(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = fmt.Errorf("recovered from panic: %w", e)
+			} else {
+				err = fmt.Errorf("recovered from panic: %v", r)
+			}
+		}
+	}()
+
+	f()
+	return nil
+}
+
+// SafeAssert converts v to T using the single-value form of a type assertion
+// (v.(T)), which panics when the dynamic type of v is not T. Try() recovers
+// from that panic and turns it into an error instead.
+//
+// In everyday code you should prefer the comma-ok form:
+//
+//	age, ok := person["age"].(int)
+//
+// but a helper like this is useful when the target type is only known at the
+// call site (dynamic dispatch), and you want a failed assertion to surface as
+// an ordinary error rather than crashing the program.
+func SafeAssert[T any](v interface{}) (result T, err error) {
+	err = Try(func() {
+		result = v.(T)
+	})
+	if err != nil {
+		return result, fmt.Errorf("safe assert to %s failed: %w", typeName[T](), err)
+	}
+	return result, nil
+}
+
+// typeName returns the name of the type T. Formatting a zero T with %T
+// doesn't work when T is an interface type such as error: the zero value is a
+// nil interface, which has no dynamic type, so %T prints "<nil>". Going
+// through a *T gets hold of the static type instead.
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
+
+// AssertAll asserts every element of items to type T, using the comma-ok
+// form. If any element has a different dynamic type, it returns an error
+// reporting the index of the first one which doesn't match.
+func AssertAll[T any](items []interface{}) ([]T, error) {
+	out := make([]T, len(items))
+	for i, item := range items {
+		v, ok := item.(T)
+		if !ok {
+			return nil, fmt.Errorf("item %d: value has type %T, not %s", i, item, typeName[T]())
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// AssertCounter keeps a tally of how many type assertions succeeded and how
+// many failed. Running it over a whole batch of map values shows how often
+// the dynamic type isn't what the code expected -- a risk that a typed
+// struct field would have ruled out at compile time.
+type AssertCounter struct {
+	Successes, Failures int
+}
+
+// AssertInt asserts that v holds an int, counting the outcome.
+func (c *AssertCounter) AssertInt(v interface{}) (int, bool) {
+	i, ok := v.(int)
+	if ok {
+		c.Successes++
+	} else {
+		c.Failures++
+	}
+	return i, ok
+}
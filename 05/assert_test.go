@@ -0,0 +1,146 @@
+package main
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestSafeAssert(t *testing.T) {
+	age, err := SafeAssert[int](21)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if age != 21 {
+		t.Fatalf("got %v; expected %v", age, 21)
+	}
+}
+
+func TestSafeAssertRecoversPanic(t *testing.T) {
+	// The non-comma-ok assertion inside SafeAssert would panic here, but the
+	// panic should be recovered and returned as an error.
+	age, err := SafeAssert[int]("Alice")
+	if err == nil {
+		t.Fatal("expected an error; got nil")
+	}
+	if age != 0 {
+		t.Fatalf("got %v; expected the zero value", age)
+	}
+
+	for _, want := range []string{"int", "string"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q does not mention %q", err, want)
+		}
+	}
+}
+
+func TestSafeAssertInterfaceType(t *testing.T) {
+	// The zero value of an interface type is nil, so the error must name
+	// the type some other way than formatting the zero value.
+	_, err := SafeAssert[error](21)
+	if err == nil {
+		t.Fatal("expected an error; got nil")
+	}
+	if exp := "safe assert to error failed"; !strings.Contains(err.Error(), exp) {
+		t.Fatalf("error %q does not mention %q", err, exp)
+	}
+}
+
+func TestTry(t *testing.T) {
+	person := map[string]interface{}{"age": "twenty-one"}
+
+	err := Try(func() {
+		_ = person["age"].(int)
+	})
+	if err == nil {
+		t.Fatal("expected an error; got nil")
+	}
+
+	// The panic from a failed type assertion is a runtime.Error, which
+	// should still be reachable through the returned error.
+	var re runtime.Error
+	if !errors.As(err, &re) {
+		t.Fatalf("got %T; expected the error to wrap a runtime.Error", err)
+	}
+}
+
+func TestTryPanicValue(t *testing.T) {
+	err := Try(func() {
+		panic("something went wrong")
+	})
+	if err == nil || !strings.Contains(err.Error(), "something went wrong") {
+		t.Fatalf("got %v; expected an error mentioning the panic value", err)
+	}
+}
+
+func TestTryNoPanic(t *testing.T) {
+	var ran bool
+	err := Try(func() {
+		ran = true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ran {
+		t.Fatal("expected f to run")
+	}
+}
+
+func TestAssertAll(t *testing.T) {
+	items := []interface{}{"Alice", "Bob", "Carol"}
+
+	names, err := AssertAll[string](items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 3 || names[0] != "Alice" || names[2] != "Carol" {
+		t.Fatalf("got %q; expected %q", names, items)
+	}
+}
+
+func TestAssertAllMismatch(t *testing.T) {
+	items := []interface{}{"Alice", 21, 167.64}
+
+	names, err := AssertAll[string](items)
+	if err == nil {
+		t.Fatal("expected an error; got nil")
+	}
+	if names != nil {
+		t.Fatalf("got %q; expected nil", names)
+	}
+	if !strings.Contains(err.Error(), "item 1") {
+		t.Fatalf("error %q does not mention item 1", err)
+	}
+}
+
+func TestAssertAllInterfaceType(t *testing.T) {
+	items := []interface{}{errors.New("boom"), "Alice"}
+
+	_, err := AssertAll[error](items)
+	if err == nil {
+		t.Fatal("expected an error; got nil")
+	}
+	if exp := "item 1: value has type string, not error"; err.Error() != exp {
+		t.Fatalf("got %q; expected %q", err, exp)
+	}
+}
+
+func TestAssertCounter(t *testing.T) {
+	values := []interface{}{21, "21", 21.0, int64(21), 0, nil, -3}
+
+	var c AssertCounter
+	sum := 0
+	for _, v := range values {
+		if i, ok := c.AssertInt(v); ok {
+			sum += i
+		}
+	}
+
+	if c.Successes != 3 || c.Failures != 4 {
+		t.Fatalf("got %d successes and %d failures; expected 3 and 4", c.Successes, c.Failures)
+	}
+	if sum != 18 {
+		t.Fatalf("got sum %d; expected 18", sum)
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import "reflect"
+
+// Coalesce returns the first of vals which is not the zero value for its
+// type, or the zero value if they all are. Because T can be any type (not
+// just a comparable one), reflection is used to check for the zero value.
+func Coalesce[T any](vals ...T) T {
+	for _, v := range vals {
+		rv := reflect.ValueOf(&v).Elem()
+		if !rv.IsZero() {
+			return v
+		}
+	}
+
+	var zero T
+	return zero
+}
+
+// GetOr returns the value stored under key in m if it is present and has the
+// dynamic type T. Otherwise it returns def.
+func GetOr[T any](m map[string]interface{}, key string, def T) T {
+	v, ok := m[key].(T)
+	if !ok {
+		return def
+	}
+	return v
+}
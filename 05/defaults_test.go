@@ -0,0 +1,55 @@
+package main
+
+import "testing"
+
+func TestCoalesce(t *testing.T) {
+	tests := []struct {
+		name string
+		vals []string
+		exp  string
+	}{
+		{"all zero", []string{"", "", ""}, ""},
+		{"first non-zero", []string{"Alice", "", "Bob"}, "Alice"},
+		{"middle non-zero", []string{"", "Bob", ""}, "Bob"},
+		{"no values", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Coalesce(tt.vals...)
+			if got != tt.exp {
+				t.Fatalf("got %q; expected %q", got, tt.exp)
+			}
+		})
+	}
+}
+
+func TestCoalesceInterface(t *testing.T) {
+	// A nil interface{} is the zero value, but an interface{} holding the int
+	// 0 is not, so it is the one that gets returned.
+	got := Coalesce[interface{}](nil, 0, 21)
+	if got != 0 {
+		t.Fatalf("got %v; expected %v", got, 0)
+	}
+}
+
+func TestGetOr(t *testing.T) {
+	person := newPerson()
+
+	if got := GetOr(person, "age", 0); got != 21 {
+		t.Fatalf("present: got %v; expected %v", got, 21)
+	}
+	if got := GetOr(person, "email", "unknown"); got != "unknown" {
+		t.Fatalf("missing: got %v; expected %v", got, "unknown")
+	}
+	if got := GetOr(person, "name", 0); got != 0 {
+		t.Fatalf("wrong type: got %v; expected %v", got, 0)
+	}
+
+	// Coalesce and GetOr combine nicely to layer defaults.
+	decoded := map[string]interface{}{"name": ""}
+	name := Coalesce(GetOr(decoded, "name", ""), GetOr(person, "name", ""), "anonymous")
+	if name != "Alice" {
+		t.Fatalf("got %q; expected %q", name, "Alice")
+	}
+}
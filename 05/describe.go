@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var (
+	describersMu sync.RWMutex
+	describers   = map[reflect.Type]func(interface{}) string{}
+)
+
+// RegisterDescriber makes Describe use fn for values whose dynamic type is
+// t, which lets new types be described without editing the switch in
+// Describe. A describer registered for one of the built-in cases takes
+// precedence over it.
+func RegisterDescriber(t reflect.Type, fn func(interface{}) string) {
+	describersMu.Lock()
+	defer describersMu.Unlock()
+	describers[t] = fn
+}
+
+// Describe returns a short human-readable description of v, such as one of
+// the values in the person map. A describer registered for the dynamic type
+// of v is used if there is one; otherwise a type switch handles the common
+// types, and anything else falls back to its type and value.
+func Describe(v interface{}) string {
+	if v != nil {
+		describersMu.RLock()
+		fn, ok := describers[reflect.TypeOf(v)]
+		describersMu.RUnlock()
+		if ok {
+			return fn(v)
+		}
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return "nothing"
+	case string:
+		return fmt.Sprintf("the string %q", v)
+	case int, int64:
+		return fmt.Sprintf("the integer %d", v)
+	case float64:
+		return fmt.Sprintf("the number %g", v)
+	case bool:
+		return fmt.Sprintf("the boolean %t", v)
+	case Person:
+		return fmt.Sprintf("%s, aged %d", v.Name, v.Age)
+	case map[string]interface{}:
+		return fmt.Sprintf("a map with %d keys", len(v))
+	default:
+		return fmt.Sprintf("a %T: %v", v, v)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+		exp  string
+	}{
+		{"nil", nil, "nothing"},
+		{"string", "Alice", `the string "Alice"`},
+		{"int", 21, "the integer 21"},
+		{"float64", 167.64, "the number 167.64"},
+		{"bool", true, "the boolean true"},
+		{"Person", Person{Name: "Alice", Age: 21}, "Alice, aged 21"},
+		{"map", newPerson(), "a map with 3 keys"},
+		{"other", []int{1, 2}, "a []int: [1 2]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Describe(tt.v); got != tt.exp {
+				t.Fatalf("got %q; expected %q", got, tt.exp)
+			}
+		})
+	}
+}
+
+type celsius float64
+
+func TestRegisterDescriber(t *testing.T) {
+	typ := reflect.TypeOf(celsius(0))
+	t.Cleanup(func() {
+		describersMu.Lock()
+		delete(describers, typ)
+		describersMu.Unlock()
+	})
+
+	// Before registering, the default case is used.
+	if got, exp := Describe(celsius(21.5)), "a main.celsius: 21.5"; got != exp {
+		t.Fatalf("got %q; expected %q", got, exp)
+	}
+
+	RegisterDescriber(typ, func(v interface{}) string {
+		return "a temperature of " + Describe(float64(v.(celsius))) + "°C"
+	})
+
+	if got, exp := Describe(celsius(21.5)), "a temperature of the number 21.5°C"; got != exp {
+		t.Fatalf("got %q; expected %q", got, exp)
+	}
+}
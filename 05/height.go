@@ -0,0 +1,25 @@
+package main
+
+import "fmt"
+
+// Height is a height in centimeters. Giving it its own type, rather than
+// using a bare float64, documents the unit and lets it have methods --
+// including String(), so that it satisfies fmt.Stringer and prints with its
+// unit.
+type Height float64 // centimeters
+
+const centimetersPerInch = 2.54
+
+func (h Height) String() string {
+	return fmt.Sprintf("%.1f cm", float64(h))
+}
+
+// Inches returns the height in inches.
+func (h Height) Inches() float64 {
+	return float64(h) / centimetersPerInch
+}
+
+// Feet returns the height in feet.
+func (h Height) Feet() float64 {
+	return h.Inches() / 12
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestHeightString(t *testing.T) {
+	tests := []struct {
+		h   Height
+		exp string
+	}{
+		{167.64, "167.6 cm"},
+		{180, "180.0 cm"},
+		{0, "0.0 cm"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.h.String(); got != tt.exp {
+			t.Fatalf("got %q; expected %q", got, tt.exp)
+		}
+	}
+
+	// Because Height satisfies fmt.Stringer, it is formatted the same way
+	// inside a Person.
+	p := Person{Name: "Alice", Age: 21, Height: 167.64}
+	if got, exp := fmt.Sprintf("%v", p), "{Alice 21 167.6 cm}"; got != exp {
+		t.Fatalf("got %q; expected %q", got, exp)
+	}
+}
+
+func TestHeightConversions(t *testing.T) {
+	tests := []struct {
+		h            Height
+		inches, feet float64
+	}{
+		{167.64, 66, 5.5},
+		{182.88, 72, 6},
+		{2.54, 1, 1.0 / 12},
+	}
+
+	const tolerance = 1e-9
+	for _, tt := range tests {
+		if got := tt.h.Inches(); math.Abs(got-tt.inches) > tolerance {
+			t.Fatalf("%v in inches: got %v; expected %v", tt.h, got, tt.inches)
+		}
+		if got := tt.h.Feet(); math.Abs(got-tt.feet) > tolerance {
+			t.Fatalf("%v in feet: got %v; expected %v", tt.h, got, tt.feet)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// MarshalPreservingInts encodes m as JSON, writing any float64 which holds a
+// whole number as an integer (22 rather than 22.0).
+//
+// This matters because decoding JSON into a map[string]interface{} turns
+// every number into a float64, so an "age" which started life as an int comes
+// back as float64(22). Converting those values to int64 before encoding means
+// the output looks the same whether the value was an int or a decoded
+// float64. Note that this only affects the encoded output: the type
+// information is still lost on decode, and you will get a float64 back again.
+func MarshalPreservingInts(m map[string]interface{}) ([]byte, error) {
+	return json.Marshal(preserveInts(m))
+}
+
+// maxSafeInt is the largest integer which a float64 can represent exactly.
+const maxSafeInt = 1 << 53
+
+// preserveInts returns a copy of v with whole-number float64 values converted
+// to int64, recursing into nested maps and slices.
+func preserveInts(v interface{}) interface{} {
+	switch v := v.(type) {
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) <= maxSafeInt {
+			return int64(v)
+		}
+		return v
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			out[k] = preserveInts(val)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			out[i] = preserveInts(val)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// MarshalPersonStable encodes the person map as indented JSON, ending with a
+// newline. encoding/json sorts map keys (including those of nested maps), so
+// the output is byte-for-byte identical every time for the same input, which
+// makes it suitable for golden files and diffs.
+//
+// Be aware that this stability only holds on the way out. If the output is
+// decoded back into a map[string]interface{}, every number becomes a float64,
+// so an int "age" will not round-trip to the same dynamic type.
+func MarshalPersonStable(p map[string]interface{}) ([]byte, error) {
+	js, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(js, '\n'), nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestMarshalPreservingInts(t *testing.T) {
+	// Decoding JSON turns the age into a float64.
+	var person map[string]interface{}
+	err := json.Unmarshal([]byte(`{"name":"Alice","age":21,"height":167.64}`), &person)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	age, ok := person["age"].(float64)
+	if !ok {
+		t.Fatalf("got %T; expected float64", person["age"])
+	}
+	person["age"] = age + 1
+
+	js, err := MarshalPreservingInts(person)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{`"age":22`, `"height":167.64`, `"name":"Alice"`} {
+		if !strings.Contains(string(js), want) {
+			t.Fatalf("%s does not contain %s", js, want)
+		}
+	}
+	if strings.Contains(string(js), "22.0") {
+		t.Fatalf("%s contains 22.0", js)
+	}
+}
+
+func TestMarshalPreservingIntsNested(t *testing.T) {
+	m := map[string]interface{}{
+		"ages": []interface{}{float64(21), float64(22)},
+		"pet":  map[string]interface{}{"age": float64(3)},
+	}
+
+	js, err := MarshalPreservingInts(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := `{"ages":[21,22],"pet":{"age":3}}`
+	if string(js) != exp {
+		t.Fatalf("got %s; expected %s", js, exp)
+	}
+}
+
+func TestMarshalPersonStable(t *testing.T) {
+	person := map[string]interface{}{
+		"name":   "Alice",
+		"age":    21,
+		"height": 167.64,
+		"address": map[string]interface{}{
+			"street": "1 Rabbit Hole",
+			"city":   "Oxford",
+		},
+		"tags": []interface{}{"reader", "explorer"},
+	}
+
+	js, err := MarshalPersonStable(person)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden := filepath.Join("testdata", "person.golden")
+	if *update {
+		err = os.WriteFile(golden, js, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	exp, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Marshal a few more times to check that the output is stable.
+	for i := 0; i < 10; i++ {
+		if !bytes.Equal(js, exp) {
+			t.Fatalf("got:\n%s\nexpected:\n%s", js, exp)
+		}
+		js, err = MarshalPersonStable(person)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
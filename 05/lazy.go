@@ -0,0 +1,61 @@
+package main
+
+// LazyPerson gives typed access to a person map. Each field is looked up and
+// converted the first time it is asked for, and the result (or the error) is
+// cached, so the map is only read once per field. The numbers are read with
+// GetNumeric and GetNumber, so a map decoded from JSON (where every number is
+// a float64) works as well as one built up in Go code. A LazyPerson is not
+// safe for concurrent use.
+type LazyPerson struct {
+	raw map[string]interface{}
+
+	name   lazyField[string]
+	age    lazyField[int]
+	height lazyField[Height]
+}
+
+// NewLazyPerson returns a LazyPerson which reads from raw.
+func NewLazyPerson(raw map[string]interface{}) *LazyPerson {
+	return &LazyPerson{raw: raw}
+}
+
+func (p *LazyPerson) Name() (string, error) {
+	return p.name.get(func() (string, error) {
+		return Get[string](p.raw, "name")
+	})
+}
+
+func (p *LazyPerson) Age() (int, error) {
+	return p.age.get(func() (int, error) {
+		age, ok := GetNumeric[int](p.raw, "age")
+		if !ok {
+			return 0, numericError(p.raw, "age", "a whole number")
+		}
+		return age, nil
+	})
+}
+
+func (p *LazyPerson) Height() (Height, error) {
+	return p.height.get(func() (Height, error) {
+		height, ok := GetNumber(p.raw, "height")
+		if !ok {
+			return 0, numericError(p.raw, "height", "a number")
+		}
+		return Height(height), nil
+	})
+}
+
+// lazyField caches the result of loading a single typed field.
+type lazyField[T any] struct {
+	loaded bool
+	v      T
+	err    error
+}
+
+func (f *lazyField[T]) get(load func() (T, error)) (T, error) {
+	if !f.loaded {
+		f.v, f.err = load()
+		f.loaded = true
+	}
+	return f.v, f.err
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestLazyPerson(t *testing.T) {
+	raw := newPerson()
+	p := NewLazyPerson(raw)
+
+	name, err := p.Name()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "Alice" {
+		t.Fatalf("got %q; expected %q", name, "Alice")
+	}
+
+	age, err := p.Age()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if age != 21 {
+		t.Fatalf("got %v; expected %v", age, 21)
+	}
+
+	height, err := p.Height()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height != 167.64 {
+		t.Fatalf("got %v; expected %v", height, 167.64)
+	}
+}
+
+func TestLazyPersonFromJSON(t *testing.T) {
+	// Every number decoded from JSON is a float64, so the age has to be
+	// converted rather than asserted to an int.
+	var raw map[string]interface{}
+	err := json.Unmarshal([]byte(`{"name":"Alice","age":21,"height":167.64}`), &raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewLazyPerson(raw)
+
+	age, err := p.Age()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if age != 21 {
+		t.Fatalf("got %v; expected %v", age, 21)
+	}
+
+	height, err := p.Height()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height != Height(167.64) {
+		t.Fatalf("got %v; expected %v", height, Height(167.64))
+	}
+}
+
+func TestLazyPersonCaches(t *testing.T) {
+	raw := newPerson()
+	p := NewLazyPerson(raw)
+
+	_, err := p.Age()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Changing the map after the first access has no effect, because the
+	// cached value is returned rather than reading the map again.
+	raw["age"] = 30
+
+	age, err := p.Age()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if age != 21 {
+		t.Fatalf("got %v; expected the cached value %v", age, 21)
+	}
+}
+
+func TestLazyPersonErrors(t *testing.T) {
+	raw := map[string]interface{}{
+		"name": 42,
+	}
+	p := NewLazyPerson(raw)
+
+	_, err := p.Name()
+	if err == nil {
+		t.Fatal("expected a type mismatch error; got nil")
+	}
+
+	// The error is cached too, even if the map is fixed afterwards.
+	raw["name"] = "Alice"
+	_, err = p.Name()
+	if err == nil {
+		t.Fatal("expected the cached error; got nil")
+	}
+
+	_, err = p.Age()
+	if !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("got %v; expected an error wrapping %v", err, ErrKeyNotFound)
+	}
+
+	// A fractional age can't be converted to an int without losing part
+	// of it.
+	p = NewLazyPerson(map[string]interface{}{"age": 21.5})
+	_, err = p.Age()
+	if err == nil {
+		t.Fatal("expected an error; got nil")
+	}
+}
@@ -20,20 +20,30 @@ func main() {
 	// invalid operation: person["age"] + 1 (mismatched types interface{} and int)
 
 	// we have to cast it back to an int to be able to add to it
-	age, ok := person["age"].(int)
+	// with person["age"].(int)... but that assertion fails if the map was
+	// decoded from JSON, where every number becomes a float64, so
+	// GetNumeric (see person.go) accepts any of the numeric types and
+	// converts the value to an int for us
+	age, ok := GetNumeric[int](person, "age")
 	if !ok {
-		log.Fatal("could not assert value to int")
+		log.Fatal("could not get value as a whole number")
 		return
 	}
 
+	// age is an int, so the dynamic type of person["age"] stays int
+	// (storing a float64 here would quietly change it -- see SetSameType)
 	person["age"] = age + 1
 
 	fmt.Printf("%+v", person)
+
+	// an alternative is a map of a small "union" type (see value.go)
+	// which keeps the values typed without needing a full struct
+	fmt.Printf("\n%v", valuePersonExample())
 }
 
 // but in this case it is better to define a Person struct with relevant typed fields
 type Person struct {
 	Name   string
 	Age    int
-	Height float32
+	Height Height // see height.go
 }
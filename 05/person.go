@@ -0,0 +1,216 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ErrKeyNotFound is the sentinel error wrapped by KeyError, so that callers
+// can check for a missing key with errors.Is(err, ErrKeyNotFound).
+var ErrKeyNotFound = errors.New("key not found")
+
+// KeyError records which key was missing from a map.
+type KeyError struct {
+	Key string
+}
+
+func (e *KeyError) Error() string {
+	return fmt.Sprintf("%q: %s", e.Key, ErrKeyNotFound)
+}
+
+func (e *KeyError) Unwrap() error {
+	return ErrKeyNotFound
+}
+
+// SetSameType writes v to m[key], but only if the key is new or the existing
+// value has the same dynamic type as v. Because the map values are
+// interface{}, Go would otherwise happily let us replace the int stored under
+// "age" with a string, and we would only find out when a later type
+// assertion fails.
+func SetSameType(m map[string]interface{}, key string, v interface{}) error {
+	existing, ok := m[key]
+	if ok && reflect.TypeOf(existing) != reflect.TypeOf(v) {
+		return fmt.Errorf("cannot set %q: existing value has type %T, new value has type %T", key, existing, v)
+	}
+
+	m[key] = v
+	return nil
+}
+
+// GetIntDetailed looks up key in m and tries to assert the value to an int.
+// Unlike the comma-ok form on its own, it lets callers tell the difference
+// between a key which is missing (present is false) and a key which holds a
+// value of some other type (present is true, correctType is false).
+func GetIntDetailed(m map[string]interface{}, key string) (value int, present bool, correctType bool) {
+	v, present := m[key]
+	if !present {
+		return 0, false, false
+	}
+
+	value, correctType = v.(int)
+	return value, true, correctType
+}
+
+// Get returns the value stored under key in m, asserted to type T. If the key
+// is missing, the error is a *KeyError (which wraps ErrKeyNotFound). If the
+// value has a different dynamic type, a type mismatch error is returned.
+func Get[T any](m map[string]interface{}, key string) (T, error) {
+	var zero T
+
+	v, ok := m[key]
+	if !ok {
+		return zero, &KeyError{Key: key}
+	}
+
+	t, ok := v.(T)
+	if !ok {
+		return zero, fmt.Errorf("%q: value has type %T, not %s", key, v, typeName[T]())
+	}
+	return t, nil
+}
+
+// GetNumber returns the value stored under key in m as a float64, accepting
+// an int, int64 or float64. This makes it tolerant of maps decoded from JSON,
+// where every number is a float64, as well as maps built up in Go code.
+func GetNumber(m map[string]interface{}, key string) (float64, bool) {
+	switch v := m[key].(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// Number is satisfied by all of Go's built-in integer and floating point
+// types, and by any type whose underlying type is one of them (that is what
+// the ~ means).
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// GetNumeric returns the value stored under key in m converted to T,
+// accepting an int, int64 or float64. The second result is false if the
+// value is missing, is not one of those types, or would change when
+// converted to T -- such as 2.5 into an int, or 300 into a uint8.
+func GetNumeric[T Number](m map[string]interface{}, key string) (T, bool) {
+	switch v := m[key].(type) {
+	case int:
+		return convertExact[T](v)
+	case int64:
+		return convertExact[T](v)
+	case float64:
+		return convertExact[T](v)
+	default:
+		return 0, false
+	}
+}
+
+// convertExact converts v to T, and reports whether converting the result
+// back gives the original value, which is only the case if nothing was lost.
+// The sign is checked as well, because a negative int converted to a uint of
+// the same size survives the round trip, even though its value has changed.
+func convertExact[T, S Number](v S) (T, bool) {
+	t := T(v)
+	if S(t) != v || (v < 0) != (t < 0) {
+		return 0, false
+	}
+	return t, true
+}
+
+// CheckTypes compares the dynamic kind of each value in m against the kind
+// expected for its key, and returns an error for every field which doesn't
+// match (including fields which are missing). The errors are sorted by key.
+// It's a way of finding out when something has written the wrong type of
+// value into a map[string]interface{}, which the compiler can't catch.
+func CheckTypes(m map[string]interface{}, expected map[string]reflect.Kind) []error {
+	keys := make([]string, 0, len(expected))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		v, ok := m[key]
+		if !ok {
+			errs = append(errs, &KeyError{Key: key})
+			continue
+		}
+
+		kind := reflect.ValueOf(v).Kind()
+		if kind != expected[key] {
+			errs = append(errs, fmt.Errorf("%q: got kind %s, expected %s", key, kind, expected[key]))
+		}
+	}
+	return errs
+}
+
+// MergeMaps returns a copy of dst with the entries from src merged into it.
+// When a key is in both maps, onConflict decides which value is kept (or
+// combines them); if onConflict is nil, the value from src wins. Neither
+// dst nor src is modified, which makes it easy to layer one map of settings
+// on top of another.
+func MergeMaps(dst, src map[string]interface{}, onConflict func(key string, dstVal, srcVal interface{}) interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(dst)+len(src))
+	for k, v := range dst {
+		out[k] = v
+	}
+
+	for k, srcVal := range src {
+		dstVal, ok := out[k]
+		if ok && onConflict != nil {
+			out[k] = onConflict(k, dstVal, srcVal)
+			continue
+		}
+		out[k] = srcVal
+	}
+	return out
+}
+
+// Snapshot builds a Person from a person map. Rather than stopping at the
+// first problem, it carries on through every field and returns all of the
+// errors together, along with a Person in which each field that could be
+// read is set. This makes it suitable for something like a form, where the
+// user should be told about every mistake at once.
+func Snapshot(m map[string]interface{}) (Person, []error) {
+	var p Person
+	var errs []error
+
+	name, err := Get[string](m, "name")
+	if err != nil {
+		errs = append(errs, err)
+	}
+	p.Name = name
+
+	age, ok := GetNumeric[int](m, "age")
+	if !ok {
+		errs = append(errs, numericError(m, "age", "a whole number"))
+	}
+	p.Age = age
+
+	height, ok := GetNumber(m, "height")
+	if !ok {
+		errs = append(errs, numericError(m, "height", "a number"))
+	}
+	p.Height = Height(height)
+
+	return p, errs
+}
+
+// numericError explains why the value under key couldn't be used as a
+// number.
+func numericError(m map[string]interface{}, key, want string) error {
+	v, ok := m[key]
+	if !ok {
+		return &KeyError{Key: key}
+	}
+	return fmt.Errorf("%q: value %v (%T) is not %s", key, v, v, want)
+}
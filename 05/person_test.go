@@ -0,0 +1,368 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newPerson() map[string]interface{} {
+	return map[string]interface{}{
+		"name":   "Alice",
+		"age":    21,
+		"height": 167.64,
+	}
+}
+
+func TestSetSameType(t *testing.T) {
+	person := newPerson()
+
+	// Writing a matching int should succeed.
+	err := SetSameType(person, "age", 22)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if person["age"] != 22 {
+		t.Fatalf("got %v; expected %v", person["age"], 22)
+	}
+
+	// Writing a string into "age" should be rejected, and leave the existing
+	// value untouched.
+	err = SetSameType(person, "age", "twenty-two")
+	if err == nil {
+		t.Fatal("expected an error; got nil")
+	}
+	if person["age"] != 22 {
+		t.Fatalf("got %v; expected %v", person["age"], 22)
+	}
+
+	// Writing to a new key is allowed whatever the type.
+	err = SetSameType(person, "email", "alice@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if person["email"] != "alice@example.com" {
+		t.Fatalf("got %v; expected %v", person["email"], "alice@example.com")
+	}
+}
+
+func TestGetIntDetailed(t *testing.T) {
+	person := newPerson()
+
+	tests := []struct {
+		name        string
+		key         string
+		value       int
+		present     bool
+		correctType bool
+	}{
+		{"int value", "age", 21, true, true},
+		{"missing key", "weight", 0, false, false},
+		{"wrong type", "name", 0, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, present, correctType := GetIntDetailed(person, tt.key)
+			if value != tt.value || present != tt.present || correctType != tt.correctType {
+				t.Fatalf("got (%v, %v, %v); expected (%v, %v, %v)",
+					value, present, correctType, tt.value, tt.present, tt.correctType)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	person := newPerson()
+
+	age, err := Get[int](person, "age")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if age != 21 {
+		t.Fatalf("got %v; expected %v", age, 21)
+	}
+
+	// A value of the wrong type is an error, but not a missing key.
+	_, err = Get[string](person, "age")
+	if err == nil {
+		t.Fatal("expected an error; got nil")
+	}
+	if errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("got %v; expected a type mismatch error", err)
+	}
+}
+
+func TestGetInterfaceType(t *testing.T) {
+	person := newPerson()
+
+	_, err := Get[error](person, "age")
+	if err == nil {
+		t.Fatal("expected an error; got nil")
+	}
+	if exp := `"age": value has type int, not error`; err.Error() != exp {
+		t.Fatalf("got %q; expected %q", err, exp)
+	}
+}
+
+func TestGetMissingKey(t *testing.T) {
+	person := newPerson()
+
+	_, err := Get[string](person, "email")
+	if !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("got %v; expected an error wrapping %v", err, ErrKeyNotFound)
+	}
+
+	var keyErr *KeyError
+	if !errors.As(err, &keyErr) {
+		t.Fatalf("got %T; expected *KeyError", err)
+	}
+	if keyErr.Key != "email" {
+		t.Fatalf("got key %q; expected %q", keyErr.Key, "email")
+	}
+}
+
+func TestGetNumber(t *testing.T) {
+	m := map[string]interface{}{
+		"int":     21,
+		"int64":   int64(21),
+		"float64": float64(21),
+		"string":  "21",
+	}
+
+	for _, key := range []string{"int", "int64", "float64"} {
+		n, ok := GetNumber(m, key)
+		if !ok || n != 21 {
+			t.Fatalf("%s: got (%v, %v); expected (21, true)", key, n, ok)
+		}
+	}
+
+	for _, key := range []string{"string", "missing"} {
+		if _, ok := GetNumber(m, key); ok {
+			t.Fatalf("%s: expected ok to be false", key)
+		}
+	}
+}
+
+func TestGetNumberFromJSON(t *testing.T) {
+	var person map[string]interface{}
+	err := json.Unmarshal([]byte(`{"name":"Alice","age":21}`), &person)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The plain int assertion fails on JSON input...
+	if _, ok := person["age"].(int); ok {
+		t.Fatal("expected the int assertion to fail")
+	}
+
+	// ...but GetNumber copes with it.
+	age, ok := GetNumber(person, "age")
+	if !ok || age+1 != 22 {
+		t.Fatalf("got (%v, %v); expected (21, true)", age, ok)
+	}
+}
+
+var personKinds = map[string]reflect.Kind{
+	"name":   reflect.String,
+	"age":    reflect.Int,
+	"height": reflect.Float64,
+}
+
+func TestGetNumeric(t *testing.T) {
+	m := map[string]interface{}{
+		"int":      42,
+		"int64":    int64(-7),
+		"whole":    float64(30),
+		"fraction": 2.5,
+		"big":      300,
+		"tenth":    0.1,
+		"name":     "Alice",
+	}
+
+	t.Run("lossless", func(t *testing.T) {
+		if got, ok := GetNumeric[int](m, "whole"); !ok || got != 30 {
+			t.Fatalf("int from float64: got %v, %v; expected 30, true", got, ok)
+		}
+		if got, ok := GetNumeric[uint8](m, "int"); !ok || got != 42 {
+			t.Fatalf("uint8 from int: got %v, %v; expected 42, true", got, ok)
+		}
+		if got, ok := GetNumeric[float32](m, "fraction"); !ok || got != 2.5 {
+			t.Fatalf("float32 from float64: got %v, %v; expected 2.5, true", got, ok)
+		}
+		if got, ok := GetNumeric[int32](m, "int64"); !ok || got != -7 {
+			t.Fatalf("int32 from int64: got %v, %v; expected -7, true", got, ok)
+		}
+	})
+
+	t.Run("lossy", func(t *testing.T) {
+		tests := []struct {
+			name string
+			get  func() bool
+		}{
+			{"fraction into int", func() bool { _, ok := GetNumeric[int](m, "fraction"); return ok }},
+			{"300 into uint8", func() bool { _, ok := GetNumeric[uint8](m, "big"); return ok }},
+			{"negative into uint", func() bool { _, ok := GetNumeric[uint](m, "int64"); return ok }},
+			{"0.1 into float32", func() bool { _, ok := GetNumeric[float32](m, "tenth"); return ok }},
+			{"string", func() bool { _, ok := GetNumeric[int](m, "name"); return ok }},
+			{"missing", func() bool { _, ok := GetNumeric[int](m, "missing"); return ok }},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if tt.get() {
+					t.Fatal("got true; expected false")
+				}
+			})
+		}
+	})
+}
+
+func TestCheckTypes(t *testing.T) {
+	errs := CheckTypes(newPerson(), personKinds)
+	if len(errs) != 0 {
+		t.Fatalf("got %v; expected no errors", errs)
+	}
+}
+
+func TestCheckTypesMismatch(t *testing.T) {
+	person := newPerson()
+	person["age"] = "twenty-one"
+	delete(person, "height")
+
+	errs := CheckTypes(person, personKinds)
+	if len(errs) != 2 {
+		t.Fatalf("got %v; expected 2 errors", errs)
+	}
+
+	if !strings.Contains(errs[0].Error(), "age") {
+		t.Fatalf("got %v; expected an error about age", errs[0])
+	}
+	if !errors.Is(errs[1], ErrKeyNotFound) {
+		t.Fatalf("got %v; expected an error wrapping %v", errs[1], ErrKeyNotFound)
+	}
+}
+
+func TestMergeMaps(t *testing.T) {
+	srcWins := func(_ string, _, srcVal interface{}) interface{} { return srcVal }
+	keepDst := func(_ string, dstVal, _ interface{}) interface{} { return dstVal }
+
+	tests := []struct {
+		name       string
+		dst, src   map[string]interface{}
+		onConflict func(string, interface{}, interface{}) interface{}
+		exp        map[string]interface{}
+	}{
+		{
+			"no overlap",
+			map[string]interface{}{"name": "Alice"},
+			map[string]interface{}{"age": 21},
+			keepDst,
+			map[string]interface{}{"name": "Alice", "age": 21},
+		},
+		{
+			"src wins",
+			map[string]interface{}{"name": "Alice", "age": 21},
+			map[string]interface{}{"age": 22},
+			srcWins,
+			map[string]interface{}{"name": "Alice", "age": 22},
+		},
+		{
+			"keep dst",
+			map[string]interface{}{"name": "Alice", "age": 21},
+			map[string]interface{}{"age": 22},
+			keepDst,
+			map[string]interface{}{"name": "Alice", "age": 21},
+		},
+		{
+			"nil resolver",
+			map[string]interface{}{"age": 21},
+			map[string]interface{}{"age": 22},
+			nil,
+			map[string]interface{}{"age": 22},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dstBefore := MergeMaps(tt.dst, nil, nil)
+
+			got := MergeMaps(tt.dst, tt.src, tt.onConflict)
+			if !reflect.DeepEqual(got, tt.exp) {
+				t.Fatalf("got %v; expected %v", got, tt.exp)
+			}
+			if !reflect.DeepEqual(tt.dst, dstBefore) {
+				t.Fatalf("dst was modified: got %v; expected %v", tt.dst, dstBefore)
+			}
+		})
+	}
+}
+
+func TestMergeMapsConflictArgs(t *testing.T) {
+	var gotKey string
+	var gotDst, gotSrc interface{}
+
+	MergeMaps(
+		map[string]interface{}{"age": 21},
+		map[string]interface{}{"age": 22},
+		func(key string, dstVal, srcVal interface{}) interface{} {
+			gotKey, gotDst, gotSrc = key, dstVal, srcVal
+			return nil
+		},
+	)
+
+	if gotKey != "age" || gotDst != 21 || gotSrc != 22 {
+		t.Fatalf("got (%q, %v, %v); expected (\"age\", 21, 22)", gotKey, gotDst, gotSrc)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	p, errs := Snapshot(newPerson())
+	if len(errs) != 0 {
+		t.Fatalf("got errors %v; expected none", errs)
+	}
+
+	exp := Person{Name: "Alice", Age: 21, Height: 167.64}
+	if p != exp {
+		t.Fatalf("got %+v; expected %+v", p, exp)
+	}
+}
+
+func TestSnapshotPartial(t *testing.T) {
+	m := map[string]interface{}{
+		"name":   "Alice",
+		"age":    "twenty-one",
+		"height": 167.64,
+	}
+
+	p, errs := Snapshot(m)
+
+	exp := Person{Name: "Alice", Height: 167.64}
+	if p != exp {
+		t.Fatalf("got %+v; expected %+v", p, exp)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), `"age"`) {
+		t.Fatalf("got errors %v; expected one about age", errs)
+	}
+}
+
+func TestSnapshotAllInvalid(t *testing.T) {
+	m := map[string]interface{}{
+		"name": 42,
+		"age":  21.5,
+	}
+
+	p, errs := Snapshot(m)
+
+	if p != (Person{}) {
+		t.Fatalf("got %+v; expected the zero Person", p)
+	}
+	if len(errs) != 3 {
+		t.Fatalf("got %d errors (%v); expected 3", len(errs), errs)
+	}
+	if !errors.Is(errs[2], ErrKeyNotFound) {
+		t.Fatalf("got %v; expected the missing height to wrap %v", errs[2], ErrKeyNotFound)
+	}
+}
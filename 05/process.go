@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// PersonResult is the outcome of converting one person map with
+// ProcessPeople: either the Person, or the error explaining why it couldn't
+// be built. It is an alias for the unnamed struct type, rather than a new
+// named type, so the two can be used interchangeably.
+type PersonResult = struct {
+	Person Person
+	Err    error
+}
+
+// ProcessPeople converts each of the maps to a Person (see Snapshot)
+// concurrently, and sends every result on the returned channel as soon as it
+// is ready, so results may arrive in any order. The channel is closed once
+// all of the maps have been handled, or once ctx is cancelled, in which case
+// any remaining results are dropped.
+func ProcessPeople(ctx context.Context, maps []map[string]interface{}) <-chan PersonResult {
+	results := make(chan PersonResult)
+
+	var wg sync.WaitGroup
+	for _, m := range maps {
+		wg.Add(1)
+		go func(m map[string]interface{}) {
+			defer wg.Done()
+
+			if ctx.Err() != nil {
+				return
+			}
+			p, errs := Snapshot(m)
+			res := PersonResult{Person: p, Err: errors.Join(errs...)}
+
+			select {
+			case results <- res:
+			case <-ctx.Done():
+			}
+		}(m)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
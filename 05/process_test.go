@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestProcessPeople(t *testing.T) {
+	maps := []map[string]interface{}{
+		{"name": "Alice", "age": 21, "height": 167.64},
+		{"name": "Bob", "age": 30, "height": 180.0},
+		{"name": "Carol", "age": "unknown", "height": 170.0},
+	}
+
+	byName := make(map[string]PersonResult)
+	for res := range ProcessPeople(context.Background(), maps) {
+		byName[res.Person.Name] = res
+	}
+
+	if len(byName) != len(maps) {
+		t.Fatalf("got %d results; expected %d", len(byName), len(maps))
+	}
+	if res := byName["Alice"]; res.Err != nil || res.Person.Age != 21 {
+		t.Fatalf("Alice: got %+v; expected age 21 and no error", res)
+	}
+	if res := byName["Bob"]; res.Err != nil || res.Person.Height != 180 {
+		t.Fatalf("Bob: got %+v; expected height 180 and no error", res)
+	}
+	if res := byName["Carol"]; res.Err == nil {
+		t.Fatalf("Carol: got %+v; expected an error for the age", res)
+	}
+}
+
+func TestProcessPeopleCancelled(t *testing.T) {
+	maps := make([]map[string]interface{}, 1000)
+	for i := range maps {
+		maps[i] = map[string]interface{}{"name": fmt.Sprint(i), "age": i, "height": 170.0}
+	}
+
+	t.Run("before starting", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		n := 0
+		for range ProcessPeople(ctx, maps) {
+			n++
+		}
+		if n != 0 {
+			t.Fatalf("got %d results; expected 0", n)
+		}
+	})
+
+	t.Run("part way", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		results := ProcessPeople(ctx, maps)
+		<-results
+		cancel()
+
+		// The channel must still be closed, so this loop ends.
+		n := 1
+		for range results {
+			n++
+		}
+		if n == len(maps) {
+			t.Fatalf("got all %d results; expected cancellation to stop early", n)
+		}
+	})
+}
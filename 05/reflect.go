@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SetField sets the field at a dotted path (such as "Address.City") on the
+// struct that ptr points to. Reflection lets us work with fields whose names
+// are only known at runtime, but we give up the compiler's checks in return,
+// so unknown fields and mismatched types are reported as errors instead.
+// Nil pointers to structs along the path are allocated as needed.
+func SetField(ptr interface{}, path string, value interface{}) error {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.New("set field: ptr must be a non-nil pointer to a struct")
+	}
+	v = v.Elem()
+
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return fmt.Errorf("set field %q: %q is not a struct", path, strings.Join(names[:i], "."))
+		}
+
+		f, ok := v.Type().FieldByName(name)
+		if !ok || !f.IsExported() {
+			return fmt.Errorf("set field %q: unknown field %q", path, name)
+		}
+		v = v.FieldByIndex(f.Index)
+	}
+
+	val := reflect.ValueOf(value)
+	if !val.IsValid() || !val.Type().AssignableTo(v.Type()) {
+		return fmt.Errorf("set field %q: cannot assign %T to field of type %s", path, value, v.Type())
+	}
+
+	v.Set(val)
+	return nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import "testing"
+
+type testAddress struct {
+	Street string
+	City   string
+}
+
+type testContact struct {
+	Name    string
+	Age     int
+	Address testAddress
+	Billing *testAddress
+}
+
+func TestSetField(t *testing.T) {
+	var c testContact
+
+	err := SetField(&c, "Name", "Alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Name != "Alice" {
+		t.Fatalf("got %q; expected %q", c.Name, "Alice")
+	}
+
+	err = SetField(&c, "Address.City", "Oxford")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Address.City != "Oxford" {
+		t.Fatalf("got %q; expected %q", c.Address.City, "Oxford")
+	}
+
+	// A nil pointer along the path is allocated.
+	err = SetField(&c, "Billing.Street", "1 Rabbit Hole")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Billing == nil || c.Billing.Street != "1 Rabbit Hole" {
+		t.Fatalf("got %+v; expected Billing.Street to be set", c.Billing)
+	}
+}
+
+func TestSetFieldErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		ptr   interface{}
+		path  string
+		value interface{}
+	}{
+		{"unknown field", &testContact{}, "Email", "alice@example.com"},
+		{"unknown nested field", &testContact{}, "Address.Postcode", "OX1"},
+		{"not a struct", &testContact{}, "Name.First", "Alice"},
+		{"type mismatch", &testContact{}, "Age", "twenty-one"},
+		{"nil value", &testContact{}, "Name", nil},
+		{"not a pointer", testContact{}, "Name", "Alice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SetField(tt.ptr, tt.path, tt.value)
+			if err == nil {
+				t.Fatal("expected an error; got nil")
+			}
+		})
+	}
+}
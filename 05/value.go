@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type valueKind int
+
+const (
+	kindInvalid valueKind = iota
+	kindInt
+	kindString
+	kindFloat
+)
+
+// Value is a small "union" type which holds exactly one of an int, a string
+// or a float64. Unlike interface{}, it can only hold those three kinds of
+// value, and the accessor methods make it impossible to forget to check which
+// kind you have got.
+//
+// The zero Value holds nothing, and all of its accessors return false.
+type Value struct {
+	kind valueKind
+	i    int
+	s    string
+	f    float64
+}
+
+func IntVal(i int) Value       { return Value{kind: kindInt, i: i} }
+func StringVal(s string) Value { return Value{kind: kindString, s: s} }
+func FloatVal(f float64) Value { return Value{kind: kindFloat, f: f} }
+
+// Int returns the int held by v, and whether v holds an int.
+func (v Value) Int() (int, bool) {
+	return v.i, v.kind == kindInt
+}
+
+// Str returns the string held by v, and whether v holds a string.
+func (v Value) Str() (string, bool) {
+	return v.s, v.kind == kindString
+}
+
+// Float returns the float64 held by v, and whether v holds a float64.
+func (v Value) Float() (float64, bool) {
+	return v.f, v.kind == kindFloat
+}
+
+// String satisfies fmt.Stringer, so a Value prints as the value it holds.
+func (v Value) String() string {
+	switch v.kind {
+	case kindInt:
+		return strconv.Itoa(v.i)
+	case kindString:
+		return v.s
+	case kindFloat:
+		return strconv.FormatFloat(v.f, 'f', -1, 64)
+	default:
+		return "<invalid>"
+	}
+}
+
+// valuePersonExample is the person example again, but using map[string]Value
+// instead of map[string]interface{}. There is still no Person struct, but the
+// age can be read back as an int without a type assertion.
+func valuePersonExample() map[string]Value {
+	person := map[string]Value{
+		"name":   StringVal("Alice"),
+		"age":    IntVal(21),
+		"height": FloatVal(167.64),
+	}
+
+	age, ok := person["age"].Int()
+	if ok {
+		person["age"] = IntVal(age + 1)
+	}
+
+	return person
+}
+
+var _ fmt.Stringer = Value{}
@@ -0,0 +1,58 @@
+package main
+
+import "testing"
+
+func TestValue(t *testing.T) {
+	if i, ok := IntVal(21).Int(); !ok || i != 21 {
+		t.Fatalf("Int: got (%v, %v); expected (21, true)", i, ok)
+	}
+	if s, ok := StringVal("Alice").Str(); !ok || s != "Alice" {
+		t.Fatalf("Str: got (%v, %v); expected (Alice, true)", s, ok)
+	}
+	if f, ok := FloatVal(167.64).Float(); !ok || f != 167.64 {
+		t.Fatalf("Float: got (%v, %v); expected (167.64, true)", f, ok)
+	}
+}
+
+func TestValueMismatchedAccessor(t *testing.T) {
+	v := StringVal("twenty-one")
+
+	if i, ok := v.Int(); ok || i != 0 {
+		t.Fatalf("Int: got (%v, %v); expected (0, false)", i, ok)
+	}
+	if f, ok := v.Float(); ok || f != 0 {
+		t.Fatalf("Float: got (%v, %v); expected (0, false)", f, ok)
+	}
+
+	var zero Value
+	if _, ok := zero.Str(); ok {
+		t.Fatal("Str: expected the zero Value not to hold a string")
+	}
+}
+
+func TestValueString(t *testing.T) {
+	tests := []struct {
+		v   Value
+		exp string
+	}{
+		{IntVal(21), "21"},
+		{StringVal("Alice"), "Alice"},
+		{FloatVal(167.64), "167.64"},
+		{Value{}, "<invalid>"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.v.String(); got != tt.exp {
+			t.Fatalf("got %q; expected %q", got, tt.exp)
+		}
+	}
+}
+
+func TestValuePersonExample(t *testing.T) {
+	person := valuePersonExample()
+
+	age, ok := person["age"].Int()
+	if !ok || age != 22 {
+		t.Fatalf("got (%v, %v); expected (22, true)", age, ok)
+	}
+}